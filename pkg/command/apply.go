@@ -16,36 +16,100 @@ package command
 
 import (
 	"errors"
-	"time"
+	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/liamawhite/licenser/pkg/config"
+	"github.com/liamawhite/licenser/pkg/file"
 	"github.com/liamawhite/licenser/pkg/license"
 	"github.com/liamawhite/licenser/pkg/processor"
 )
 
 var (
 	isDryRun     bool
+	spdxID       string
+	spdxShort    bool
 	templatePath string
 	markerString string
+
+	headerMaxLine int
+	prologues     []string
+	fixPlacement  bool
+	addHolder     bool
+	addNotice     bool
+	canonical     bool
 )
 
 var applyCmd = &cobra.Command{
-	Use:   "apply [-t <template file> -m <license-mark>] <copyright-owner>",
+	Use:   "apply [-l <spdx-id>] [--spdx] [-t <template file> -m <license-mark>] [<copyright-owner>...]",
 	Short: "Apply licenses to files in your directory",
-	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) < 1 {
-			return errors.New("not enough arguments passed")
-		}
-		return nil
-	},
+	Long: `Apply licenses to files in your directory.
+
+Files whose header has a different license or names none of the copyright owners, such as
+files forked from another project, are left as they are. With --modification-notice a notice,
+"Modifications copyright <year> <owner>" by default, is added beneath their header instead.
+
+Files matching the licenses mapped to paths in the config file get the mapped license instead.
+
+If no copyright owner is passed, each file's owner is the first found of:
+  - the owner of the last matching rule in the owners file, .licenser-owners by default
+  - owner in the config file
+  - the first entry in the AUTHORS file
+  - git config user.organization, then user.name
+`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		handler, err := newHandler(templatePath, markerString, args[0])
+		handler, err := newHandler(spdxID, spdxShort, templatePath, markerString, firstOwner(args))
+		if err != nil {
+			return err
+		}
+		handler = withOwners(handler, args)
+
+		c, err := config.Load(configPath)
+		if err != nil {
+			return err
+		}
+
+		opts, err := fileOptions()
+		if err != nil {
+			return err
+		}
+
+		years, err := yearStrategy(copyrightYear)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, file.WithFixPlacement(fixPlacement), file.WithAddHolders(addHolder), file.WithYearStrategy(years),
+			file.WithOwners(args...), file.WithCanonicalHolders(canonical))
+		licenses, err := licenseStrategy(c, args)
 		if err != nil {
 			return err
 		}
+		opts = append(opts, file.WithLicenseStrategy(licenses))
+		languages, err := languageLicenses(c, args)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, languages...)
+		if len(args) == 0 {
+			owners, err := ownerStrategy(c)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, file.WithOwnerStrategy(owners))
+		}
+		if addNotice {
+			notice, err := c.Notice()
+			if err != nil {
+				return err
+			}
+			opts = append(opts, file.WithModificationNotice(notice))
+		}
 
-		l := processor.New(".", handler)
+		l := processor.New(".", handler, opts...)
 		if ok := l.Apply(recurseDirectories, isDryRun); !ok {
 			return errors.New("error applying license")
 		}
@@ -56,20 +120,75 @@ var applyCmd = &cobra.Command{
 
 func init() {
 	applyCmd.Flags().BoolVarP(&isDryRun, "dry-run", "d", false, "output result to stdout")
+	applyCmd.Flags().StringVarP(&spdxID, "license", "l", "", "SPDX ID of the builtin license to use, e.g. MIT. By default Apache-2.0 is used")
+	applyCmd.Flags().BoolVar(&spdxShort, "spdx", false, "use an SPDX short header (SPDX-License-Identifier and SPDX-FileCopyrightText) instead of the full license text. --license may then be any SPDX license expression")
 	applyCmd.Flags().StringVarP(&templatePath, "license-template", "t", "", "license template file to use. By default Apache 2.0 license template is used")
-	applyCmd.Flags().StringVarP(&markerString, "license-mark", "m", "", "substring to check for to validate the presence of the license header")
+	applyCmd.Flags().StringVarP(&markerString, "license-mark", "m", "", "substring to check for to validate the presence of the license header. Defaults to the builtin license's marker")
+	addPlacementFlags(applyCmd)
+	addNearestLicenseFlag(applyCmd)
+	addYearFlag(applyCmd)
+	applyCmd.Flags().BoolVar(&fixPlacement, "fix-placement", false, "move misplaced license headers to the top of the file and remove duplicates")
+	applyCmd.Flags().BoolVar(&addHolder, "add-holder", false, "add the copyright owners' notices to existing license headers that don't name them")
+	applyCmd.Flags().BoolVar(&addNotice, "modification-notice", false, "add a modification notice beneath headers with a different license or owner, configured with modificationNotice in the config file")
+	applyCmd.Flags().BoolVar(&canonical, "canonical-holders", false, "rewrite copyright notices naming the owners in another form, e.g. \"(c) Acme Inc\" for \"Acme, Inc.\", to name them exactly as given")
+	addVarFlag(applyCmd)
 	rootCmd.AddCommand(applyCmd)
 }
 
-func newHandler(template, marker, owner string) (license.Handler, error) {
-	var h license.Handler
-	if template == "" {
-		h = license.NewApache20(time.Now().Year(), owner)
-	} else {
+func newHandler(spdxID string, short bool, template, marker, owner string) (license.Handler, error) {
+	year, err := file.CurrentYear()
+	if err != nil {
+		return nil, err
+	}
+	if short {
+		if template != "" {
+			return nil, errors.New("--license-template can't be used with --spdx")
+		}
+		if spdxID == "" {
+			spdxID = "Apache-2.0"
+		}
+		if problems := license.ValidateExpression(spdxID); len(problems) > 0 {
+			return nil, fmt.Errorf("invalid license expression %q: %v", spdxID, problems[0])
+		}
+		return license.NewSPDX(spdxID, year, owner), nil
+	}
+	if spdxID != "" {
+		builtin, ok := license.LookupBuiltin(spdxID)
+		if !ok {
+			return nil, fmt.Errorf("unknown license %q, must be one of %v", spdxID, strings.Join(license.BuiltinIDs(), ", "))
+		}
 		if marker == "" {
-			return nil, errors.New("--license-mark is required when using --license-template")
+			marker = builtin.Marker
 		}
-		h = license.FromTemplateFile(template, marker, time.Now().Year(), owner)
+		if template == "" {
+			h := builtin.Handler(year, owner)
+			h.MarkerText = marker
+			return h, nil
+		}
+	}
+
+	if template == "" {
+		return license.NewApache20(year, owner), nil
+	}
+	if marker == "" {
+		return nil, errors.New("--license-mark is required when using --license-template without --license")
+	}
+	h, err := license.FromTemplateFile(template, marker, year, owner)
+	if err != nil {
+		return nil, err
 	}
 	return h, nil
 }
+
+func newPlacement(maxLine int, extraPrologues []string) (file.Placement, error) {
+	placement := file.DefaultPlacement()
+	placement.MaxLine = maxLine
+	for _, p := range extraPrologues {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return placement, fmt.Errorf("invalid --prologue %q: %v", p, err)
+		}
+		placement.Prologues = append(placement.Prologues, re)
+	}
+	return placement, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/liamawhite/licenser/pkg/file"
+	"github.com/liamawhite/licenser/pkg/processor"
+)
+
+var detectCmd = &cobra.Command{
+	Use:   "detect",
+	Short: "Detect which license each file in your directory carries",
+	Long: `Detect which license each file in your directory carries.
+
+Each file's header is classified against the builtin licenses. Files with an
+SPDX-License-Identifier are reported with its expression, headers that mention
+a license or copyright but aren't recognised are reported as "unknown" and
+files without a header as "none". A summary of the number of files per license
+follows the per file results.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		placement, err := newPlacement(headerMaxLine, prologues)
+		if err != nil {
+			return err
+		}
+
+		var mu sync.Mutex
+		detected := map[string]string{}
+		p := processor.NewWalker(".")
+		ok := p.Walk(recurseDirectories, func(path string) bool {
+			classification, ok := file.Detect(path, placement)
+			if ok {
+				mu.Lock()
+				detected[path] = classification
+				mu.Unlock()
+			}
+			return true
+		})
+
+		paths := []string{}
+		counts := map[string]int{}
+		for path, classification := range detected {
+			paths = append(paths, path)
+			counts[classification]++
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			fmt.Printf("%v: %v\n", path, detected[path])
+		}
+
+		licenses := []string{}
+		for classification := range counts {
+			licenses = append(licenses, classification)
+		}
+		sort.Slice(licenses, func(i, j int) bool {
+			if counts[licenses[i]] != counts[licenses[j]] {
+				return counts[licenses[i]] > counts[licenses[j]]
+			}
+			return licenses[i] < licenses[j]
+		})
+		fmt.Println()
+		for _, classification := range licenses {
+			fmt.Printf("%v: %d file(s)\n", classification, counts[classification])
+		}
+
+		if !ok {
+			return fmt.Errorf("error detecting licenses")
+		}
+		return nil
+	},
+}
+
+func init() {
+	addPlacementFlags(detectCmd)
+	rootCmd.AddCommand(detectCmd)
+}
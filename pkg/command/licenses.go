@@ -0,0 +1,174 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/liamawhite/licenser/pkg/config"
+	"github.com/liamawhite/licenser/pkg/file"
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+const (
+	nearestExpect = "expect"
+	nearestSkip   = "skip"
+)
+
+var nearestLicense string
+
+// addNearestLicenseFlag adds the flag choosing licenses from the nearest license file to the command
+func addNearestLicenseFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&nearestLicense, "nearest-license", "", "use the license file (LICENSE, COPYING, ...) closest to each file below the current directory: expect (its license) or skip (files whose license file has a different license)")
+}
+
+// licenseStrategy builds the strategy choosing each file's license, first from the config's license mappings
+// and then from the nearest license file. It returns nil if neither is used.
+func licenseStrategy(c *config.Config, owners []string) (file.LicenseStrategy, error) {
+	mapped, err := mappedLicenses(c, owners)
+	if err != nil {
+		return nil, err
+	}
+	nearest, err := nearestLicenses(nearestLicense, owners)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case mapped == nil:
+		return nearest, nil
+	case nearest == nil:
+		return mapped, nil
+	}
+	return file.FirstLicense(mapped, nearest), nil
+}
+
+// mappedLicenses builds the strategy choosing each file's license from the config's license mappings.
+// Mappings without an owner name the owners passed on the command line. It returns nil if there are no mappings.
+func mappedLicenses(c *config.Config, owners []string) (file.LicenseStrategy, error) {
+	if len(c.Licenses) == 0 {
+		return nil, nil
+	}
+	match, err := file.ParseLicenseMatch(c.LicenseMatch)
+	if err != nil {
+		return nil, err
+	}
+	licenses, err := file.NewPathLicenses(".", match)
+	if err != nil {
+		return nil, err
+	}
+	for i, mapping := range c.Licenses {
+		if len(mapping.Paths) == 0 {
+			return nil, fmt.Errorf("license mapping %d has no paths", i+1)
+		}
+		rule := file.LicenseRule{Owner: mapping.Owner, Skip: mapping.Skip}
+		if !mapping.Skip {
+			handler, err := newHandler(mapping.License, mapping.SPDX, mapping.Template, mapping.Marker, firstOwner(owners))
+			if err != nil {
+				return nil, fmt.Errorf("license mapping for %v: %v", mapping.Paths, err)
+			}
+			rule.Handler = withOwners(handler, owners)
+		}
+		licenses.Add(mapping.Paths, rule)
+	}
+	return licenses.Rule, nil
+}
+
+// nearestLicenses builds the strategy choosing each file's license from its nearest license file below the
+// current directory. Files whose license file has the license given on the command line get it as configured.
+// With expect other licenses are expected instead, and files whose license file isn't recognised are skipped.
+// With skip every file whose license file has another license is skipped. It returns nil if mode is empty.
+func nearestLicenses(mode string, owners []string) (file.LicenseStrategy, error) {
+	expected := spdxID
+	if expected == "" && templatePath == "" {
+		expected = "Apache-2.0"
+	}
+	var rule func(id string) *file.LicenseRule
+	switch mode {
+	case "":
+		return nil, nil
+	case nearestExpect:
+		rule = func(id string) *file.LicenseRule {
+			if strings.EqualFold(id, expected) {
+				return nil
+			}
+			if id == license.Unknown || id == license.None {
+				return &file.LicenseRule{Skip: true}
+			}
+			_, builtin := license.LookupBuiltin(id)
+			handler, err := newHandler(id, spdxShort || !builtin, "", "", firstOwner(owners))
+			if err != nil {
+				return &file.LicenseRule{Skip: true}
+			}
+			return &file.LicenseRule{Handler: withOwners(handler, owners)}
+		}
+	case nearestSkip:
+		rule = func(id string) *file.LicenseRule {
+			if strings.EqualFold(id, expected) {
+				return nil
+			}
+			return &file.LicenseRule{Skip: true}
+		}
+	default:
+		return nil, fmt.Errorf("unknown --nearest-license %q, must be %q or %q", mode, nearestExpect, nearestSkip)
+	}
+	nearest, err := file.NewNearestLicenses(".", rule)
+	if err != nil {
+		return nil, err
+	}
+	return nearest.Rule, nil
+}
+
+// languageLicenses builds the options overriding the license written into files in the languages the config
+// sets one for. Languages without a license ID use the one given on the command line.
+func languageLicenses(c *config.Config, owners []string) ([]file.Option, error) {
+	if err := c.CheckLanguages(); err != nil {
+		return nil, err
+	}
+	opts := []file.Option{}
+	for name, language := range c.Languages {
+		if !language.HasLicense() {
+			continue
+		}
+		id := language.License
+		if id == "" {
+			id = spdxID
+		}
+		handler, err := newHandler(id, language.SPDX, language.Template, language.Marker, firstOwner(owners))
+		if err != nil {
+			return nil, fmt.Errorf("license for %v: %v", name, err)
+		}
+		opts = append(opts, file.WithLanguageLicense(name, withOwners(handler, owners)))
+	}
+	return opts, nil
+}
+
+// firstOwner returns the first of the owners, or "" if there are none
+func firstOwner(owners []string) string {
+	if len(owners) == 0 {
+		return ""
+	}
+	return owners[0]
+}
+
+// withOwners names all the owners in the handler, if there's more than one and the handler supports it
+func withOwners(handler license.Handler, owners []string) license.Handler {
+	if owned, ok := handler.(license.Owned); ok && len(owners) > 1 {
+		return owned.WithOwners(owners...)
+	}
+	return handler
+}
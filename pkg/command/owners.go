@@ -0,0 +1,107 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/liamawhite/licenser/pkg/config"
+	"github.com/liamawhite/licenser/pkg/file"
+	"github.com/liamawhite/licenser/pkg/license"
+	"github.com/liamawhite/licenser/pkg/processor"
+)
+
+var (
+	ownersOutput   string
+	ownersExamples int
+)
+
+var ownersCmd = &cobra.Command{
+	Use:   "owners [-o text|json]",
+	Short: "List the copyright holders of files in your directory",
+	Long: `List the copyright holders of files in your directory.
+
+The copyright notices in each file's header comments are gathered by holder,
+with the number of files naming them, the span of years in their notices and
+some example files. Holders written in different forms, e.g. "Acme Inc" and
+"Acme, Inc.", and the ownerAliases in the config file are counted together.
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if ownersOutput != "text" && ownersOutput != "json" {
+			return fmt.Errorf("unknown output %q, must be text or json", ownersOutput)
+		}
+		c, err := config.Load(configPath)
+		if err != nil {
+			return err
+		}
+		placement, err := newPlacement(headerMaxLine, prologues)
+		if err != nil {
+			return err
+		}
+
+		var mu sync.Mutex
+		inventory := license.NewInventory(license.NewHolders(c.OwnerAliases))
+		p := processor.NewWalker(".")
+		ok := p.Walk(recurseDirectories, func(path string) bool {
+			notices, ok := file.Copyrights(path, placement)
+			if ok {
+				mu.Lock()
+				inventory.Add(path, notices)
+				mu.Unlock()
+			}
+			return true
+		})
+
+		summary := inventory.Summary(ownersExamples)
+		if ownersOutput == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(summary); err != nil {
+				return err
+			}
+		} else {
+			for _, s := range summary {
+				years := ""
+				if s.Years != "" {
+					years = ", " + s.Years
+				}
+				fmt.Printf("%v: %d file(s)%s\n", s.Holder, s.Files, years)
+				if len(s.Examples) > 0 {
+					fmt.Printf("  %s\n", strings.Join(s.Examples, "\n  "))
+				}
+			}
+		}
+
+		if !ok {
+			return errors.New("error listing copyright holders")
+		}
+		return nil
+	},
+}
+
+func init() {
+	ownersCmd.Flags().StringVarP(&ownersOutput, "output", "o", "text", "output format, text or json")
+	ownersCmd.Flags().IntVar(&ownersExamples, "examples", 3, "number of example files to list for each holder")
+	addPlacementFlags(ownersCmd)
+	rootCmd.AddCommand(ownersCmd)
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/liamawhite/licenser/pkg/file"
+	"github.com/liamawhite/licenser/pkg/processor"
+)
+
+var (
+	fromLicense  string
+	fromSPDX     bool
+	fromTemplate string
+	fromMark     string
+	toLicense    string
+	toSPDX       bool
+)
+
+var relicenseCmd = &cobra.Command{
+	Use:   "relicense (--from <spdx-id> | --from-template <file> --from-mark <text>) --to <spdx-id> [<copyright-owner>]",
+	Short: "Replace one license header with another in files in your directory",
+	Long: `Replace one license header with another in files in your directory.
+
+Only files whose header matches the --from license are changed. Custom headers,
+such as a proprietary notice, are matched with --from-template and --from-mark
+instead. The copyright notices in the existing header are kept, the copyright
+owner argument is only used for headers that don't have one. Duplicate copies of
+the old header are removed. Use --dry-run to see the changes as a diff without
+writing them.
+`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return errors.New("too many arguments passed")
+		}
+		if (fromLicense == "" && fromTemplate == "") || toLicense == "" {
+			return errors.New("--from or --from-template, and --to are required")
+		}
+		if fromTemplate != "" && fromLicense == "" && fromMark == "" {
+			return errors.New("--from-mark is required when using --from-template without --from")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		owner := ""
+		if len(args) == 1 {
+			owner = args[0]
+		}
+		from, err := newHandler(fromLicense, fromSPDX, fromTemplate, fromMark, "")
+		if err != nil {
+			return err
+		}
+		to, err := newHandler(toLicense, toSPDX, "", "", owner)
+		if err != nil {
+			return err
+		}
+
+		opts, err := fileOptions()
+		if err != nil {
+			return err
+		}
+
+		years, err := yearStrategy(copyrightYear)
+		if err != nil {
+			return err
+		}
+
+		l := processor.New(".", to, append(opts, file.WithYearStrategy(years))...)
+		if ok := l.Relicense(from, recurseDirectories, isDryRun); !ok {
+			return errors.New("error relicensing")
+		}
+		return nil
+	},
+}
+
+func init() {
+	relicenseCmd.Flags().BoolVarP(&isDryRun, "dry-run", "d", false, "print the changes as a diff rather than writing them")
+	relicenseCmd.Flags().StringVar(&fromLicense, "from", "", "SPDX ID of the builtin license to replace")
+	relicenseCmd.Flags().BoolVar(&fromSPDX, "from-spdx", false, "the license to replace is an SPDX short header, --from may be any SPDX license expression")
+	relicenseCmd.Flags().StringVar(&fromTemplate, "from-template", "", "license template file of the header to replace, as with apply's --license-template")
+	relicenseCmd.Flags().StringVar(&fromMark, "from-mark", "", "substring identifying the header to replace, as with apply's --license-mark. Defaults to the --from license's marker")
+	relicenseCmd.Flags().StringVar(&toLicense, "to", "", "SPDX ID of the builtin license to replace it with")
+	relicenseCmd.Flags().BoolVar(&toSPDX, "to-spdx", false, "replace it with an SPDX short header, --to may be any SPDX license expression")
+	addPlacementFlags(relicenseCmd)
+	addYearFlag(relicenseCmd)
+	addVarFlag(relicenseCmd)
+	rootCmd.AddCommand(relicenseCmd)
+}
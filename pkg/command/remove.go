@@ -0,0 +1,61 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"github.com/liamawhite/licenser/pkg/processor"
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove [-l <spdx-id>] [--spdx] [-t <template file> -m <license-mark>]",
+	Short: "Remove licenses from files in your directory",
+	Long: `Remove licenses from files in your directory.
+
+Only headers matching the license are removed, along with the blank line
+separating them from the rest of the file. Prologues such as shebangs are kept.
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := newHandler(spdxID, spdxShort, templatePath, markerString, "")
+		if err != nil {
+			return err
+		}
+
+		opts, err := fileOptions()
+		if err != nil {
+			return err
+		}
+
+		l := processor.New(".", handler, opts...)
+		if ok := l.Remove(recurseDirectories, isDryRun); !ok {
+			return errors.New("error removing license")
+		}
+		return nil
+	},
+}
+
+func init() {
+	removeCmd.Flags().BoolVarP(&isDryRun, "dry-run", "d", false, "output result to stdout")
+	removeCmd.Flags().StringVarP(&spdxID, "license", "l", "", "SPDX ID of the builtin license to remove, e.g. MIT. By default Apache-2.0 is used")
+	removeCmd.Flags().BoolVar(&spdxShort, "spdx", false, "remove an SPDX short header whose SPDX-License-Identifier matches --license, which may be any SPDX license expression")
+	removeCmd.Flags().StringVarP(&templatePath, "license-template", "t", "", "license template file to use. By default Apache 2.0 license template is used")
+	removeCmd.Flags().StringVarP(&markerString, "license-mark", "m", "", "substring to check for to find the license header. Defaults to the builtin license's marker")
+	addPlacementFlags(removeCmd)
+	rootCmd.AddCommand(removeCmd)
+}
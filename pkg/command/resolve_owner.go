@@ -0,0 +1,63 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"errors"
+	"os"
+
+	"github.com/liamawhite/licenser/pkg/config"
+	"github.com/liamawhite/licenser/pkg/file"
+)
+
+// authorsFiles are the names an AUTHORS file may have, in order of preference
+var authorsFiles = []string{"AUTHORS", "AUTHORS.md", "AUTHORS.txt"}
+
+// ownerStrategy resolves the copyright owner of each file when none is passed on the command line.
+// In order it tries the rules in the owners file, the config's owner, the first entry in the AUTHORS file
+// and the git user's organization or name.
+func ownerStrategy(c *config.Config) (file.OwnerStrategy, error) {
+	strategies := []file.OwnerStrategy{}
+	rules, err := c.OwnerRules()
+	if err != nil {
+		return nil, err
+	}
+	if rules != nil {
+		strategies = append(strategies, rules.Owner)
+	}
+	if c.Owner != "" {
+		strategies = append(strategies, file.FixedOwner(c.Owner))
+	}
+	for _, name := range authorsFiles {
+		author, err := file.ReadAuthors(name)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if author != "" {
+			strategies = append(strategies, file.FixedOwner(author))
+		}
+		break
+	}
+	if owner := file.GitOwner("."); owner != "" {
+		strategies = append(strategies, file.FixedOwner(owner))
+	}
+	if len(strategies) == 0 {
+		return nil, errors.New("no copyright owner passed and none found in the owners file, config file, AUTHORS file or git config")
+	}
+	return file.FirstOwner(strategies...), nil
+}
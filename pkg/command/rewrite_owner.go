@@ -0,0 +1,164 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/liamawhite/licenser/pkg/file"
+	"github.com/liamawhite/licenser/pkg/license"
+	"github.com/liamawhite/licenser/pkg/processor"
+)
+
+// renames are the --rename and --rename-regex values in the order given on the command line
+var renames []renameArg
+
+// renameArg is the value of a --rename, or if regex a --rename-regex, flag
+type renameArg struct {
+	value string
+	regex bool
+}
+
+// renameFlag appends the values of its flag to renames, so both flags share one ordered list
+type renameFlag struct {
+	regex bool
+}
+
+func (f renameFlag) String() string {
+	return ""
+}
+
+func (f renameFlag) Set(value string) error {
+	renames = append(renames, renameArg{value: value, regex: f.regex})
+	return nil
+}
+
+func (f renameFlag) Type() string {
+	return "stringArray"
+}
+
+var rewriteOwnerCmd = &cobra.Command{
+	Use:   "rewrite-owner --rename <old>=<new> [--rename-regex <pattern>=<new>]",
+	Short: "Rename the copyright owner in existing headers",
+	Long: `Rename the copyright owner in existing headers.
+
+Copyright notices in the header comments of each file are matched against the
+renames in the order given, the first matching rename applies. --rename matches
+the owner exactly, --rename-regex matches the whole owner against a regular
+expression and the new owner may refer to its submatches, e.g. "$1". The years
+in each notice are kept. A summary of the owners renamed follows.
+`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(renames) == 0 {
+			return errors.New("at least one --rename or --rename-regex is required")
+		}
+		return cobra.NoArgs(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		table, err := ownerRenames(renames)
+		if err != nil {
+			return err
+		}
+		placement, err := newPlacement(headerMaxLine, prologues)
+		if err != nil {
+			return err
+		}
+
+		var mu sync.Mutex
+		rewritten := map[string][]file.OwnerRewrite{}
+		p := processor.NewWalker(".")
+		ok := p.Walk(recurseDirectories, func(path string) bool {
+			rewrites, ok := file.RewriteOwners(path, table, placement, isDryRun)
+			if len(rewrites) > 0 {
+				mu.Lock()
+				rewritten[path] = rewrites
+				mu.Unlock()
+			}
+			return ok
+		})
+
+		lines := 0
+		files := map[string]map[string]bool{}
+		for path, rewrites := range rewritten {
+			for _, r := range rewrites {
+				rename := r.From + " -> " + r.To
+				if files[rename] == nil {
+					files[rename] = map[string]bool{}
+				}
+				files[rename][path] = true
+				lines++
+			}
+		}
+		summary := []string{}
+		for rename := range files {
+			summary = append(summary, rename)
+		}
+		sort.Strings(summary)
+		if isDryRun {
+			fmt.Println()
+		}
+		for _, rename := range summary {
+			fmt.Printf("%v: %d file(s)\n", rename, len(files[rename]))
+		}
+		verb := "rewrote"
+		if isDryRun {
+			verb = "would rewrite"
+		}
+		fmt.Printf("%s %d copyright notice(s) in %d file(s)\n", verb, lines, len(rewritten))
+
+		if !ok {
+			return errors.New("error rewriting copyright owners")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rewriteOwnerCmd.Flags().BoolVarP(&isDryRun, "dry-run", "d", false, "print the changes as a diff rather than writing them")
+	rewriteOwnerCmd.Flags().Var(renameFlag{}, "rename", "rename an owner, given as <old>=<new>, e.g. \"OldCo Inc.=NewCo LLC\". May be repeated")
+	rewriteOwnerCmd.Flags().Var(renameFlag{regex: true}, "rename-regex", "rename owners matching a regular expression, given as <pattern>=<new>, e.g. \"OldCo( Inc\\.?)?=NewCo LLC\". May be repeated")
+	addPlacementFlags(rewriteOwnerCmd)
+	rootCmd.AddCommand(rewriteOwnerCmd)
+}
+
+// ownerRenames builds the rename table from the --rename and --rename-regex flags, in the order they were given
+func ownerRenames(args []renameArg) (license.OwnerRenames, error) {
+	table := license.OwnerRenames{}
+	for _, arg := range args {
+		from, to, ok := strings.Cut(arg.value, "=")
+		if !arg.regex {
+			if !ok || strings.TrimSpace(from) == "" {
+				return nil, fmt.Errorf("invalid --rename %q, must be <old>=<new>", arg.value)
+			}
+			table = append(table, license.ExactRename(from, strings.TrimSpace(to)))
+			continue
+		}
+		if !ok || from == "" {
+			return nil, fmt.Errorf("invalid --rename-regex %q, must be <pattern>=<new>", arg.value)
+		}
+		rename, err := license.RegexRename(from, strings.TrimSpace(to))
+		if err != nil {
+			return nil, err
+		}
+		table = append(table, rename)
+	}
+	return table, nil
+}
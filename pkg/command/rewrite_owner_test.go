@@ -0,0 +1,45 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnerRenames_Order(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"exact first", []string{"--rename", "OldCo=Exact Corp", "--rename-regex", "Old.*=Pattern Corp"}, "Exact Corp"},
+		{"pattern first", []string{"--rename-regex", "Old.*=Pattern Corp", "--rename", "OldCo=Exact Corp"}, "Pattern Corp"},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			renames = nil
+			defer func() { renames = nil }()
+			assert.NoError(t, rewriteOwnerCmd.ParseFlags(tc.args))
+			table, err := ownerRenames(renames)
+			assert.NoError(t, err)
+			got, ok := table.Rename("OldCo")
+			assert.True(t, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -17,12 +17,18 @@ package command
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+
+	"github.com/liamawhite/licenser/pkg/config"
+	"github.com/liamawhite/licenser/pkg/file"
 )
 
 var (
 	recurseDirectories bool
+	configPath         string
+	templateVars       []string
 )
 
 var rootCmd = &cobra.Command{
@@ -40,4 +46,50 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&recurseDirectories, "recurse", "r", false, "recurse from the passed directory")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "config file to use. By default "+config.DefaultPath+" is used if present")
+}
+
+// addPlacementFlags adds the flags controlling where license headers may be to the command
+func addPlacementFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&headerMaxLine, "header-max-line", file.DefaultPlacement().MaxLine, "last line the license header may start on")
+	cmd.Flags().StringArrayVar(&prologues, "prologue", nil, "regular expression matching lines allowed before the license header, in addition to shebangs and encoding declarations")
+}
+
+// addVarFlag adds the flag setting license template variables to the command
+func addVarFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&templateVars, "var", nil, "license template variable, given as <key>=<value> and used as {{.Vars.key}}. Overrides vars in the config file. May be repeated")
+}
+
+// parseVars parses the --var flags
+func parseVars(flags []string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, v := range flags {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --var %q, must be <key>=<value>", v)
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+	return vars, nil
+}
+
+// fileOptions builds the file mutator options shared by commands from the config file and flags
+func fileOptions() ([]file.Option, error) {
+	c, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := c.FileOptions()
+	if err != nil {
+		return nil, err
+	}
+	placement, err := newPlacement(headerMaxLine, prologues)
+	if err != nil {
+		return nil, err
+	}
+	vars, err := parseVars(templateVars)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, file.WithPlacement(placement), file.WithTemplateVars(vars), file.WithGitHistory(history)), nil
 }
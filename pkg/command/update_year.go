@@ -0,0 +1,105 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/liamawhite/licenser/pkg/file"
+	"github.com/liamawhite/licenser/pkg/license"
+	"github.com/liamawhite/licenser/pkg/processor"
+)
+
+const (
+	policyAlways   = "always"
+	policyModified = "modified"
+	policyGit      = "git"
+)
+
+var (
+	yearPolicy  string
+	replaceYear bool
+)
+
+var updateYearCmd = &cobra.Command{
+	Use:   "update-year [-l <spdx-id>] [--spdx] [-t <template file> -m <license-mark>] [--policy always|modified|git]",
+	Short: "Bring the copyright years in existing license headers up to date",
+	Long: `Bring the copyright years in existing license headers up to date.
+
+The copyright notices in headers matching the license are extended to the year
+chosen by --policy, e.g. "2019" becomes "2019-2026":
+  - always:   the current year
+  - modified: the year the file was last modified, so only files modified this year get the current year
+  - git:      the year of the file's last commit
+
+With --replace the years are replaced rather than extended. The current year is
+the year of SOURCE_DATE_EPOCH if set.
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := newHandler(spdxID, spdxShort, templatePath, markerString, "")
+		if err != nil {
+			return err
+		}
+
+		opts, err := fileOptions()
+		if err != nil {
+			return err
+		}
+
+		years, err := policyStrategy(yearPolicy)
+		if err != nil {
+			return err
+		}
+
+		l := processor.New(".", handler, append(opts, file.WithYearStrategy(years))...)
+		if ok := l.UpdateYear(recurseDirectories, replaceYear, isDryRun); !ok {
+			return errors.New("error updating copyright years")
+		}
+		return nil
+	},
+}
+
+func init() {
+	updateYearCmd.Flags().BoolVarP(&isDryRun, "dry-run", "d", false, "print the changes as a diff rather than writing them")
+	updateYearCmd.Flags().StringVarP(&spdxID, "license", "l", "", "SPDX ID of the builtin license whose headers to update, e.g. MIT. By default Apache-2.0 is used")
+	updateYearCmd.Flags().BoolVar(&spdxShort, "spdx", false, "update SPDX short headers whose SPDX-License-Identifier matches --license, which may be any SPDX license expression")
+	updateYearCmd.Flags().StringVarP(&templatePath, "license-template", "t", "", "license template file to use. By default Apache 2.0 license template is used")
+	updateYearCmd.Flags().StringVarP(&markerString, "license-mark", "m", "", "substring to check for to find the license header. Defaults to the builtin license's marker")
+	updateYearCmd.Flags().StringVar(&yearPolicy, "policy", policyAlways, "which year to bring headers up to: always, modified or git")
+	updateYearCmd.Flags().BoolVar(&replaceYear, "replace", false, "replace the years rather than extending them to a range")
+	addPlacementFlags(updateYearCmd)
+	rootCmd.AddCommand(updateYearCmd)
+}
+
+// policyStrategy builds the strategy for the --policy flag's value
+func policyStrategy(policy string) (file.YearStrategy, error) {
+	current, err := file.CurrentYear()
+	if err != nil {
+		return nil, err
+	}
+	switch policy {
+	case policyAlways:
+		return file.FixedYear(license.Year(current)), nil
+	case policyModified:
+		return file.ModifiedYear(current), nil
+	case policyGit:
+		return file.GitYear(history, current, true), nil
+	}
+	return nil, fmt.Errorf("unknown --policy %q, must be one of %q, %q or %q", policy, policyAlways, policyModified, policyGit)
+}
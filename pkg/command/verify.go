@@ -20,11 +20,22 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/liamawhite/licenser/pkg/config"
+	"github.com/liamawhite/licenser/pkg/file"
+	"github.com/liamawhite/licenser/pkg/license"
 	"github.com/liamawhite/licenser/pkg/processor"
 )
 
+var (
+	similarityThreshold float64
+	strict              bool
+	owners              []string
+	validYears          string
+	checkYears          bool
+)
+
 var verifyCmd = &cobra.Command{
-	Use:   "verify [-t <template file> -m <license-mark>]",
+	Use:   "verify [-l <spdx-id>] [--spdx] [-t <template file> -m <license-mark>]",
 	Short: "Verify licenses are present in files in your directory",
 	Long: `Verify licenses are present in files in your directory.
 	
@@ -34,14 +45,49 @@ Verify will ignore the following files:
   - Files that should be ignored according to .gitignore (experimental)
   - .licenserignore
   - Files that should be ignored according to .licenserignore (experimental)
+
+Verify fails if a license header is missing, duplicated, or not the first thing
+in the file (after any allowed prologue such as a shebang) within --header-max-line lines.
+With --strict the header must also be formatted as configured (blank lines, banners and spacing).
+With --owner every copyright notice in the header must name one of the owners, and with --years
+or --check-years its years must be in the range given, not in the future and not before the file's
+first commit.
+Files matching the licenses mapped to paths in the config file must have the mapped license instead.
+Headers that closely resemble the license are reported with their similarity and differing lines.
 `,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		handler, err := newHandler(templatePath, markerString, "")
+		handler, err := newHandler(spdxID, spdxShort, templatePath, markerString, "")
+		if err != nil {
+			return err
+		}
+
+		opts, err := fileOptions()
 		if err != nil {
 			return err
 		}
 
-		l := processor.New(".", handler)
+		opts = append(opts, file.WithSimilarityThreshold(similarityThreshold), file.WithStrict(strict))
+		copyrightOpts, err := copyrightOptions(owners, validYears, checkYears)
+		if err != nil {
+			return err
+		}
+
+		c, err := config.Load(configPath)
+		if err != nil {
+			return err
+		}
+		licenses, err := licenseStrategy(c, nil)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, file.WithLicenseStrategy(licenses))
+		languages, err := languageLicenses(c, nil)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, languages...)
+
+		l := processor.New(".", handler, append(opts, copyrightOpts...)...)
 		if ok := l.Verify(recurseDirectories); !ok {
 			os.Exit(1)
 		}
@@ -52,7 +98,39 @@ Verify will ignore the following files:
 }
 
 func init() {
+	verifyCmd.Flags().StringVarP(&spdxID, "license", "l", "", "SPDX ID of the builtin license to use, e.g. MIT. By default Apache-2.0 is used")
+	verifyCmd.Flags().BoolVar(&spdxShort, "spdx", false, "expect an SPDX short header whose SPDX-License-Identifier matches --license, which may be any SPDX license expression")
 	verifyCmd.Flags().StringVarP(&templatePath, "license-template", "t", "", "license template file to use. By default Apache 2.0 license template is used")
-	verifyCmd.Flags().StringVarP(&markerString, "license-mark", "m", "", "substring to check for to validate the presence of the license header")
+	verifyCmd.Flags().StringVarP(&markerString, "license-mark", "m", "", "substring to check for to validate the presence of the license header. Defaults to the builtin license's marker")
+	addPlacementFlags(verifyCmd)
+	addNearestLicenseFlag(verifyCmd)
+	verifyCmd.Flags().Float64Var(&similarityThreshold, "similarity-threshold", file.DefaultSimilarityThreshold, "lowest similarity (0-1) at which a header is reported as differing from the license rather than missing")
+	verifyCmd.Flags().BoolVar(&strict, "strict", false, "also verify the header is formatted according to the config file")
+	verifyCmd.Flags().StringArrayVar(&owners, "owner", nil, "copyright holder every copyright notice must name, may be repeated to allow several")
+	verifyCmd.Flags().StringVar(&validYears, "years", "", "range copyright years must fall within, e.g. 2015-2026")
+	verifyCmd.Flags().BoolVar(&checkYears, "check-years", false, "report copyright years in the future or before the file was first committed")
 	rootCmd.AddCommand(verifyCmd)
 }
+
+// copyrightOptions builds the options checking copyright notices from the verify flags
+func copyrightOptions(owners []string, years string, history bool) ([]file.Option, error) {
+	opts := []file.Option{}
+	if len(owners) > 0 {
+		opts = append(opts, file.WithOwners(owners...))
+	}
+	if years != "" {
+		r, err := license.ParseYearRange(years)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --years: %v", err)
+		}
+		opts = append(opts, file.WithValidYears(r))
+	}
+	if history {
+		current, err := file.CurrentYear()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, file.WithYearHistory(current))
+	}
+	return opts, nil
+}
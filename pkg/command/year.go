@@ -0,0 +1,63 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/liamawhite/licenser/pkg/file"
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+const (
+	yearGit      = "git"
+	yearGitRange = "git-range"
+	yearModified = "modified"
+)
+
+var copyrightYear string
+
+// addYearFlag adds the flag controlling the copyright year written into headers to the command
+func addYearFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&copyrightYear, "year", "", "copyright year to write: a year such as 2019, a range such as 2019-2026, git (year each file was first committed), git-range (years of its first and last commits) or modified (year it was last modified). Defaults to the current year, or the year of SOURCE_DATE_EPOCH if set")
+}
+
+// history caches the git history of files for the git year strategies and the mutator
+var history = file.NewGitHistory()
+
+// yearStrategy builds the strategy for the --year flag's value
+func yearStrategy(value string) (file.YearStrategy, error) {
+	current, err := file.CurrentYear()
+	if err != nil {
+		return nil, err
+	}
+	switch value {
+	case "":
+		return file.FixedYear(license.Year(current)), nil
+	case yearGit:
+		return file.GitYear(history, current, false), nil
+	case yearGitRange:
+		return file.GitYear(history, current, true), nil
+	case yearModified:
+		return file.ModifiedYear(current), nil
+	}
+	r, err := license.ParseYearRange(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --year: %v", err)
+	}
+	return file.FixedYear(r), nil
+}
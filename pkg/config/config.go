@@ -0,0 +1,211 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/liamawhite/licenser/pkg/file"
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// DefaultPath is the config file used when one isn't passed explicitly
+const DefaultPath = ".licenser.json"
+
+// Config is the contents of a licenser config file
+type Config struct {
+	// Format is the header format used for all languages
+	Format Format `json:"format"`
+
+	// Languages holds per language overrides keyed by language name, e.g. "golang"
+	Languages map[string]Language `json:"languages"`
+
+	// ModificationNotice is the template for the notice apply adds beneath foreign headers
+	ModificationNotice string `json:"modificationNotice,omitempty"`
+
+	// Owner is the copyright owner apply names when none is passed
+	Owner string `json:"owner,omitempty"`
+
+	// OwnersFile is the file mapping paths to copyright owners, .licenser-owners by default
+	OwnersFile string `json:"ownersFile,omitempty"`
+
+	// Licenses maps paths to the license expected in them, for trees mixing licenses
+	Licenses []LicenseMapping `json:"licenses,omitempty"`
+
+	// LicenseMatch is how the mapping is chosen for files several match: "first" (the default) or "specific"
+	LicenseMatch string `json:"licenseMatch,omitempty"`
+
+	// ProjectName is the project name license templates can use as .ProjectName
+	ProjectName string `json:"projectName,omitempty"`
+
+	// Vars are user defined values license templates can use as .Vars.key
+	Vars map[string]string `json:"vars,omitempty"`
+
+	// OwnerAliases lists other names for copyright holders keyed by their canonical name
+	OwnerAliases map[string][]string `json:"ownerAliases,omitempty"`
+}
+
+// LicenseMapping is the license expected in the files matching any of its paths. Where several mappings
+// match a file, Config.LicenseMatch chooses between them.
+type LicenseMapping struct {
+	// Paths are gitignore style patterns relative to the current directory. A pattern matching a
+	// directory matches everything beneath it, and the longest pattern decides "specific" matches.
+	Paths []string `json:"paths"`
+
+	// License, SPDX, Template and Marker are the license, as with the apply flags of the same names
+	License  string `json:"license,omitempty"`
+	SPDX     bool   `json:"spdx,omitempty"`
+	Template string `json:"template,omitempty"`
+	Marker   string `json:"marker,omitempty"`
+
+	// Owner, if set, is named in place of the copyright owner otherwise used, and verify checks the
+	// header's notices name it. Like the license, it comes from the one mapping chosen for the file.
+	Owner string `json:"owner,omitempty"`
+
+	// Skip leaves the files alone. A file is only skipped if the mapping chosen for it skips it, so with
+	// "specific" a more specific mapping beneath a skipped directory still applies.
+	Skip bool `json:"skip,omitempty"`
+}
+
+// Language is the configuration for a single language
+type Language struct {
+	Format
+
+	// License, SPDX, Template and Marker, if any are set, override the license written into files in the
+	// language. License defaults to the one given on the command line.
+	License  string `json:"license,omitempty"`
+	SPDX     bool   `json:"spdx,omitempty"`
+	Template string `json:"template,omitempty"`
+	Marker   string `json:"marker,omitempty"`
+}
+
+// HasLicense returns true if the language overrides the license
+func (l Language) HasLicense() bool {
+	return l.License != "" || l.SPDX || l.Template != ""
+}
+
+// Format is the JSON form of file.Format, unset fields are inherited
+type Format struct {
+	BlankLine       *string `json:"blankLine,omitempty"`
+	Banner          *string `json:"banner,omitempty"`
+	BlankLinesAfter *int    `json:"blankLinesAfter,omitempty"`
+	Width           *int    `json:"width,omitempty"`
+}
+
+// Load reads the config at path. If path is empty, the default path is used if it exists
+// and an empty config is returned if it doesn't.
+func Load(path string) (*Config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = DefaultPath
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("unable to read config %v: %v", path, err)
+	}
+	c := &Config{}
+	if err := json.Unmarshal(contents, c); err != nil {
+		return nil, fmt.Errorf("unable to parse config %v: %v", path, err)
+	}
+	return c, nil
+}
+
+// CheckLanguages returns an error if the config has settings for a language licenser doesn't know, such as
+// "go" for "golang", which would otherwise be silently ignored
+func (c *Config) CheckLanguages() error {
+	for name := range c.Languages {
+		if !file.IsLanguage(name) {
+			return fmt.Errorf("unknown language %q in languages, must be one of %v", name, strings.Join(file.Languages(), ", "))
+		}
+	}
+	return nil
+}
+
+// FileOptions returns the mutator options described by the config
+func (c *Config) FileOptions() ([]file.Option, error) {
+	if err := c.CheckLanguages(); err != nil {
+		return nil, err
+	}
+	base := c.Format.apply(file.DefaultFormat())
+	if err := base.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid format: %v", err)
+	}
+	opts := []file.Option{file.WithFormat(base), file.WithHolderAliases(license.NewHolders(c.OwnerAliases)), file.WithTemplateVars(c.Vars)}
+	if c.ProjectName != "" {
+		opts = append(opts, file.WithProjectName(c.ProjectName))
+	}
+	for name, language := range c.Languages {
+		format := language.Format.apply(base)
+		if err := format.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid format for %v: %v", name, err)
+		}
+		opts = append(opts, file.WithLanguageFormat(name, format))
+	}
+	return opts, nil
+}
+
+// apply overlays the fields that are set onto the passed format
+func (f Format) apply(to file.Format) file.Format {
+	if f.BlankLine != nil {
+		to.BlankLine = file.BlankLineStyle(*f.BlankLine)
+	}
+	if f.Banner != nil {
+		to.Banner = *f.Banner
+	}
+	if f.BlankLinesAfter != nil {
+		to.BlankLinesAfter = *f.BlankLinesAfter
+	}
+	if f.Width != nil {
+		to.Width = *f.Width
+	}
+	return to
+}
+
+// Notice returns the modification notice template, or the default if one isn't configured
+func (c *Config) Notice() (*template.Template, error) {
+	notice := c.ModificationNotice
+	if notice == "" {
+		notice = file.DefaultModificationNotice
+	}
+	tmpl, err := file.ParseModificationNotice(notice)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modification notice: %v", err)
+	}
+	return tmpl, nil
+}
+
+// OwnerRules loads the owners file. It returns nil if the config doesn't name one and the default doesn't exist.
+func (c *Config) OwnerRules() (*file.OwnerRules, error) {
+	path := c.OwnersFile
+	if path == "" {
+		path = file.DefaultOwnersFile
+	}
+	rules, err := file.LoadOwnerRules(path)
+	if c.OwnersFile == "" && errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read owners file: %v", err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/file"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("Explicit path must exist", func(t *testing.T) {
+		_, err := Load("testdata/missing.json")
+		assert.Error(t, err)
+	})
+
+	t.Run("Language formats inherit the base format", func(t *testing.T) {
+		c, err := Load("testdata/config.json")
+		assert.NoError(t, err)
+		base := c.Format.apply(file.DefaultFormat())
+		assert.Equal(t, file.Format{BlankLine: file.BlankLineBare, Banner: "----", BlankLinesAfter: 1}, base)
+		golang := c.Languages["golang"].Format.apply(base)
+		assert.Equal(t, file.Format{BlankLine: file.BlankLineSpace, Banner: "----", BlankLinesAfter: 2}, golang)
+	})
+}
+
+func TestFileOptions(t *testing.T) {
+	three, unknown := 3, "dashed"
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"empty", Config{}, false},
+		{"too many blank lines", Config{Format: Format{BlankLinesAfter: &three}}, true},
+		{"unknown blank line style", Config{Languages: map[string]Language{"golang": {Format: Format{BlankLine: &unknown}}}}, true},
+		{"known language", Config{Languages: map[string]Language{"golang": {License: "MIT"}}}, false},
+		{"unknown language", Config{Languages: map[string]Language{"go": {License: "MIT"}}}, true},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.config.FileOptions()
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
+
+func TestNotice(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		want    string
+		wantErr bool
+	}{
+		{"default", Config{}, file.DefaultModificationNotice, false},
+		{"configured", Config{ModificationNotice: "Changed by {{.Owner}}"}, "Changed by {{.Owner}}", false},
+		{"invalid", Config{ModificationNotice: "Changed by {{.Owner"}, "", true},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.config.Notice()
+			assert.Equal(t, tc.wantErr, err != nil)
+			if err == nil {
+				assert.Equal(t, tc.want, got.Root.String())
+			}
+		})
+	}
+}
+
+func TestOwnerRules(t *testing.T) {
+	t.Run("Default owners file is optional", func(t *testing.T) {
+		rules, err := (&Config{}).OwnerRules()
+		assert.NoError(t, err)
+		assert.Nil(t, rules)
+	})
+
+	t.Run("Configured owners file must exist", func(t *testing.T) {
+		_, err := (&Config{OwnersFile: "testdata/missing-owners"}).OwnerRules()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// fileContext describes the file at path for license templates. Paths are relative to the current
+// directory, which is also the default project name.
+func (m *Mutator) fileContext(path string, style *languageStyle) license.FileContext {
+	ctx := license.FileContext{FileName: filepath.Base(path), Language: style.name, ProjectName: m.projectName, Vars: m.vars}
+	if cwd, err := filepath.Abs("."); err == nil {
+		if rel, ok := relativeTo(cwd, path); ok {
+			ctx.RelPath = filepath.ToSlash(rel)
+		}
+		if ctx.ProjectName == "" {
+			ctx.ProjectName = filepath.Base(cwd)
+		}
+	}
+	var once sync.Once
+	var year int
+	var author string
+	ctx.History = func() (int, string) {
+		once.Do(func() {
+			if history := m.history.commits(path); len(history) > 0 {
+				first := history[len(history)-1]
+				year, author = first.year, first.author
+			}
+		})
+		return year, author
+	}
+	return ctx
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// copyrightLine is a copyright notice found in a header, with the zero based line it's on
+type copyrightLine struct {
+	line   int
+	notice license.Copyright
+}
+
+// findCopyrights returns the copyright notices in the header block
+func findCopyrights(lines [][]byte, block headerBlock, style *languageStyle) []copyrightLine {
+	found := []copyrightLine{}
+	for i := block.start; i < block.end; i++ {
+		if c, ok := license.ParseCopyright(string(uncomment(lines[i:i+1], style))); ok {
+			found = append(found, copyrightLine{line: i, notice: c})
+		}
+	}
+	return found
+}
+
+// addNotices inserts the handler's copyright notices that are missing from the header block after the
+// block's last notice, written with the same prefix as it, e.g. "Copyright (c)". Returns nil if the header
+// already names every holder, and an error if the license can't be rendered.
+func (m *Mutator) addNotices(in inspection, style *languageStyle, handler license.Handler) ([]byte, error) {
+	rendered, err := license.Render(handler)
+	if err != nil {
+		return nil, err
+	}
+	block := in.blocks[0]
+	existing := findCopyrights(in.lines, block, style)
+	at, prefix := block.start, ""
+	if len(existing) > 0 {
+		last := existing[len(existing)-1]
+		at, prefix = last.line+1, last.notice.Prefix
+	}
+
+	added := [][]byte{}
+	for _, c := range license.FindCopyrights(string(rendered)) {
+		if m.names(existing, c.Holder) {
+			continue
+		}
+		if prefix != "" {
+			c.Prefix = prefix
+		}
+		added = append(added, []byte(style.comment+" "+c.String()))
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+	return joinLines(append(append(append([][]byte{}, in.lines[:at]...), added...), in.lines[at:]...)), nil
+}
+
+// names returns true if one of the notices names the holder, or one of its aliases
+func (m *Mutator) names(notices []copyrightLine, holder string) bool {
+	for _, c := range notices {
+		if m.holders.Same(c.notice.Holder, holder) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCopyrights returns a description of each way the copyright notices in the header block
+// don't meet the expected owners and years
+func (m *Mutator) checkCopyrights(path string, lines [][]byte, block headerBlock, style *languageStyle) []string {
+	if len(m.owners) == 0 && m.validYears == nil && m.currentYear == 0 {
+		return nil
+	}
+	notices := findCopyrights(lines, block, style)
+	if len(notices) == 0 {
+		return []string{fmt.Sprintf("no copyright notice in license header in %v", path)}
+	}
+
+	firstCommit := 0
+	if m.currentYear > 0 {
+		if history := m.history.commits(path); len(history) > 0 {
+			firstCommit = history[len(history)-1].year
+		}
+	}
+
+	problems := []string{}
+	for _, c := range notices {
+		where := fmt.Sprintf("in %v on line %d", path, c.line+1)
+		if _, ok := m.owner(c.notice.Holder); len(m.owners) > 0 && !ok {
+			problems = append(problems, fmt.Sprintf("copyright holder %s is %q, expected %s", where, c.notice.Holder, quoteList(m.owners, "or")))
+		}
+		span := c.notice.Span()
+		if span.First == 0 {
+			continue
+		}
+		if m.validYears != nil && (span.First < m.validYears.First || span.Last > m.validYears.Last) {
+			problems = append(problems, fmt.Sprintf("copyright years %s %s are outside %v", c.notice.Years, where, m.validYears))
+		}
+		if m.currentYear > 0 && span.Last > m.currentYear {
+			problems = append(problems, fmt.Sprintf("copyright year %d %s is in the future", span.Last, where))
+		}
+		if firstCommit > 0 && span.First < firstCommit {
+			problems = append(problems, fmt.Sprintf("copyright year %d %s is before the file was first committed in %d", span.First, where, firstCommit))
+		}
+	}
+	return problems
+}
+
+// owner returns the expected owner the holder refers to, or false if it's none of them
+func (m *Mutator) owner(holder string) (string, bool) {
+	for _, owner := range m.owners {
+		if m.holders.Same(owner, holder) {
+			return owner, true
+		}
+	}
+	return "", false
+}
+
+// canonicalize rewrites the copyright notices in the header block that name one of the expected owners
+// in some other form, e.g. "(c) Acme Inc" for "Acme, Inc.", to name the owner as given and use the
+// license's copyright prefix. It returns true if any line changed, and an error if the license can't be rendered.
+func (m *Mutator) canonicalize(lines [][]byte, block headerBlock, style *languageStyle, handler license.Handler) (bool, error) {
+	prefix := ""
+	rendered, err := license.Render(handler)
+	if err != nil {
+		return false, err
+	}
+	if notices := license.FindCopyrights(string(rendered)); len(notices) > 0 {
+		prefix = notices[0].Prefix
+	}
+	changed := false
+	for _, c := range findCopyrights(lines, block, style) {
+		owner, ok := m.owner(c.notice.Holder)
+		if !ok {
+			continue
+		}
+		canonical := c.notice
+		canonical.Holder = m.holders.Canonical(owner)
+		if prefix != "" {
+			canonical.Prefix = prefix
+		}
+		if canonical.String() != c.notice.String() {
+			lines[c.line] = []byte(style.comment + " " + canonical.String())
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// quoteList formats the items as e.g. `"a", "b" or "c"`
+func quoteList(items []string, conjunction string) string {
+	quoted := []string{}
+	for _, item := range items {
+		quoted = append(quoted, fmt.Sprintf("%q", item))
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " " + conjunction + " " + quoted[len(quoted)-1]
+}
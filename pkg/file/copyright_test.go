@@ -0,0 +1,127 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+func TestMutator_checkCopyrights(t *testing.T) {
+	header := "# Copyright 2019 Liam White\n# Copyright 2023-2030 Our Corp\n# Licensed under the Apache License, Version 2.0\n"
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{"nothing to check", nil, nil},
+		{"owners match", []Option{WithOwners("liam white", "Our Corp")}, []string{}},
+		{"unexpected owner", []Option{WithOwners("Liam White")}, []string{
+			`copyright holder in test.sh on line 2 is "Our Corp", expected "Liam White"`,
+		}},
+		{"outside years", []Option{WithValidYears(license.YearRange{First: 2020, Last: 2026})}, []string{
+			"copyright years 2019 in test.sh on line 1 are outside 2020-2026",
+			"copyright years 2023-2030 in test.sh on line 2 are outside 2020-2026",
+		}},
+		{"future", []Option{WithYearHistory(2026)}, []string{
+			"copyright year 2030 in test.sh on line 2 is in the future",
+		}},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			m := New(license.NewApache20(0, ""), tc.opts...)
+			lines := splitLines([]byte(header))
+			got := m.checkCopyrights("test.sh", lines, headerBlock{start: 0, end: len(lines)}, commentStyles["shell"])
+			if tc.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestMutator_checkCopyrights_NoNotice(t *testing.T) {
+	m := New(license.NewApache20(0, ""), WithOwners("Liam White"))
+	lines := splitLines([]byte("# Licensed under the Apache License, Version 2.0\n"))
+	got := m.checkCopyrights("test.sh", lines, headerBlock{start: 0, end: 1}, commentStyles["shell"])
+	assert.Equal(t, []string{"no copyright notice in license header in test.sh"}, got)
+}
+
+func Test_addNotices(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     string
+	}{
+		{
+			name:     "added under existing notice",
+			contents: "# Copyright (c) 2019 Liam White\n# Licensed under the Apache License, Version 2.0\n\necho hi\n",
+			want:     "# Copyright (c) 2019 Liam White\n# Copyright (c) 2026 Our Corp\n# Licensed under the Apache License, Version 2.0\n\necho hi\n",
+		},
+		{
+			name:     "already named",
+			contents: "# Copyright 2019 Liam White\n# Copyright 2020 our corp\n# Licensed under the Apache License, Version 2.0\n",
+		},
+	}
+	handler := license.NewApache20(2026, "Our Corp")
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			in := inspect([]byte(tc.contents), handler, testLayout())
+			got, err := New(handler).addNotices(in, commentStyles["shell"], handler)
+			assert.NoError(t, err)
+			if tc.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+
+	t.Run("license can't be rendered", func(t *testing.T) {
+		broken, err := license.FromTemplateString("Copyright {{.Year}} {{.Onwer}}\n", "Copyright", 2026, "Our Corp")
+		assert.NoError(t, err)
+		in := inspect([]byte("# Copyright 2019 Liam White\n\necho hi\n"), broken, testLayout())
+		_, err = New(broken).addNotices(in, commentStyles["shell"], broken)
+		assert.Error(t, err)
+	})
+}
+
+func TestMutator_canonicalize(t *testing.T) {
+	header := "# (c) 2019 Acme Inc\n# Copyright 2020 Acme Widgets. All rights reserved.\n# Copyright 2021 Other Corp\n# Licensed under the Apache License, Version 2.0\n"
+	want := "# Copyright 2019 Acme, Inc.\n# Copyright 2020 Acme, Inc. All rights reserved.\n# Copyright 2021 Other Corp\n# Licensed under the Apache License, Version 2.0\n"
+
+	handler := license.NewApache20(2026, "Acme, Inc.")
+	m := New(handler, WithOwners("Acme, Inc."), WithHolderAliases(license.NewHolders(map[string][]string{"Acme, Inc.": {"Acme Widgets"}})))
+	lines := splitLines([]byte(header))
+	changed, err := m.canonicalize(lines, headerBlock{start: 0, end: len(lines)}, commentStyles["shell"], handler)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, want, string(joinLines(lines)))
+
+	changed, err = m.canonicalize(lines, headerBlock{start: 0, end: len(lines)}, commentStyles["shell"], handler)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	broken, err := license.FromTemplateString("Copyright {{.Year}} {{.Onwer}}\n", "Copyright", 2026, "Acme, Inc.")
+	assert.NoError(t, err)
+	_, err = m.canonicalize(lines, headerBlock{start: 0, end: len(lines)}, commentStyles["shell"], broken)
+	assert.Error(t, err)
+}
@@ -0,0 +1,47 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// detectGaps is the number of blank lines a header may span when detecting, as we don't know how it was formatted
+const detectGaps = 10
+
+// Detect classifies the license header of the file at path against the builtin licenses,
+// returning license.Unknown or license.None if it isn't recognised.
+// It returns false if the file's language can't be identified or it can't be read.
+func Detect(path string, placement Placement) (string, bool) {
+	style := identifyLanguageStyle(path)
+	if style == nil {
+		return "", false
+	}
+	contents := getFileContents(path)
+	if contents == nil {
+		return "", false
+	}
+	l := layout{style: style, format: DefaultFormat(), placement: placement, gaps: detectGaps}
+	lines := splitLines(contents)
+	for _, block := range commentBlocks(lines, l, 0) {
+		if block.start >= placement.MaxLine {
+			break
+		}
+		if classification := license.Classify(string(uncomment(lines[block.start:block.end], style))); classification != license.None {
+			return classification, true
+		}
+	}
+	return license.None, true
+}
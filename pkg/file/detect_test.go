@@ -0,0 +1,69 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+func TestDetect(t *testing.T) {
+	shell, golang := commentStyles["shell"], commentStyles["golang"]
+	apache := string(DefaultFormat().render(license.NewApache20(2019, "Test").Reader(), shell))
+	mit := DefaultFormat().render(license.NewSPDX("MIT", 2019, "Test").Reader(), shell)
+	goMIT := DefaultFormat().render(license.NewSPDX("MIT", 2019, "Test").Reader(), golang)
+	tmp, err := ioutil.TempDir("", "detect")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	tests := []struct {
+		name     string
+		file     string
+		contents string
+		want     string
+		wantOk   bool
+	}{
+		{"Apache header", "apache.sh", apache + "\necho hi\n", "Apache-2.0", true},
+		{"after a shebang", "shebang.sh", "#!/bin/bash\n\n" + apache + "\necho hi\n", "Apache-2.0", true},
+		{"MIT header", "mit.go", string(goMIT) + "\npackage main\n", "MIT", true},
+		{"dual licensed", "dual.go", "// SPDX-License-Identifier: MIT OR Apache-2.0\n\npackage main\n", "MIT OR Apache-2.0", true},
+		{"two license headers", "mixed.sh", string(mit) + "\n" + apache + "\necho hi\n", "MIT", true},
+		{"copyright only", "proprietary.sh", "# Copyright 2019 Test\n# All rights reserved.\n\necho hi\n", license.Unknown, true},
+		{"no header", "none.sh", "echo hi\n", license.None, true},
+		{"license past the max line", "late.sh", "echo hi\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n\n" + apache, license.None, true},
+		{"unknown language", "notes.unknown", apache, "", false},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(tmp, tc.file)
+			assert.NoError(t, ioutil.WriteFile(path, []byte(tc.contents), 0644))
+			got, ok := Detect(path, DefaultPlacement())
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, ok := Detect(filepath.Join(tmp, "missing.sh"), DefaultPlacement())
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// BlankLineStyle controls how blank lines in the license text are written
+type BlankLineStyle string
+
+const (
+	// BlankLineBare writes the comment prefix on its own, e.g. "//"
+	BlankLineBare BlankLineStyle = "bare"
+	// BlankLineSpace writes the comment prefix followed by a space, e.g. "// "
+	BlankLineSpace BlankLineStyle = "space"
+	// BlankLineEmpty writes an empty line with no comment prefix
+	BlankLineEmpty BlankLineStyle = "empty"
+)
+
+// Format controls how the license header is laid out in a file
+type Format struct {
+	// BlankLine is how blank lines within the license are written
+	BlankLine BlankLineStyle
+
+	// Banner, if set, is written as a comment line above and below the license, e.g. "----"
+	Banner string
+
+	// BlankLinesAfter is the number of blank lines between the license and the rest of the file
+	BlankLinesAfter int
+
+	// Width, if set, is the column limit license paragraphs are reflowed to, including the comment prefix.
+	// Indented lines and lines containing URLs are never wrapped.
+	Width int
+}
+
+// DefaultFormat writes blank license lines as a bare comment prefix, with no banner
+// and a single blank line after the header.
+func DefaultFormat() Format {
+	return Format{BlankLine: BlankLineBare, BlankLinesAfter: 1}
+}
+
+// Validate returns an error if the format can't be used
+func (f Format) Validate() error {
+	switch f.BlankLine {
+	case BlankLineBare, BlankLineSpace, BlankLineEmpty:
+	default:
+		return fmt.Errorf("unknown blank line style %q, must be one of %q, %q or %q", f.BlankLine, BlankLineBare, BlankLineSpace, BlankLineEmpty)
+	}
+	if f.BlankLinesAfter < 0 || f.BlankLinesAfter > 2 {
+		return fmt.Errorf("blank lines after the license must be 0, 1 or 2, got %d", f.BlankLinesAfter)
+	}
+	if f.Width < 0 {
+		return fmt.Errorf("width must not be negative, got %d", f.Width)
+	}
+	return nil
+}
+
+// render comments out each line of the license according to the format
+func (f Format) render(license io.Reader, style *languageStyle) []byte {
+	buf := bytes.NewBuffer([]byte{})
+	if f.Banner != "" {
+		_, _ = buf.WriteString(f.bannerLine(style))
+		_, _ = buf.WriteString("\n")
+	}
+	// Consecutive lines of a paragraph are joined before wrapping, so the wrapped text reads evenly
+	paragraph := []string{}
+	writeWrapped := func(text string) {
+		for _, line := range f.wrap(text, style) {
+			_, _ = buf.WriteString(style.comment)
+			_, _ = buf.WriteString(" ")
+			_, _ = buf.WriteString(line)
+			_, _ = buf.WriteString("\n")
+		}
+	}
+	flush := func() {
+		if len(paragraph) > 0 {
+			writeWrapped(strings.Join(paragraph, " "))
+			paragraph = paragraph[:0]
+		}
+	}
+	scanner := bufio.NewScanner(license)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			flush()
+			_, _ = buf.WriteString(f.blankLine(style))
+			_, _ = buf.WriteString("\n")
+			continue
+		}
+		if f.reflows(scanner.Text()) {
+			paragraph = append(paragraph, scanner.Text())
+			continue
+		}
+		flush()
+		writeWrapped(scanner.Text())
+	}
+	flush()
+	if f.Banner != "" {
+		_, _ = buf.WriteString(f.bannerLine(style))
+		_, _ = buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+// reflows returns true if the line is part of a paragraph that may be joined with its neighbours before
+// wrapping. Indented lines, lines containing URLs and copyright notices keep their own lines.
+func (f Format) reflows(text string) bool {
+	return f.Width > 0 && strings.TrimLeft(text, " \t") == text && !strings.Contains(text, "://") &&
+		!strings.HasPrefix(strings.ToLower(text), "copyright")
+}
+
+// wrap splits a line of license text so it fits within the width once commented out.
+// Words longer than the width are left whole rather than broken.
+func (f Format) wrap(text string, style *languageStyle) []string {
+	limit := f.Width - len(style.comment) - 1
+	if f.Width == 0 || len(text) <= limit || strings.TrimLeft(text, " \t") != text || strings.Contains(text, "://") {
+		return []string{text}
+	}
+	lines := []string{}
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > limit {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	return append(lines, line)
+}
+
+func (f Format) bannerLine(style *languageStyle) string {
+	return style.comment + " " + f.Banner
+}
+
+func (f Format) blankLine(style *languageStyle) string {
+	switch f.BlankLine {
+	case BlankLineSpace:
+		return style.comment + " "
+	case BlankLineEmpty:
+		return ""
+	default:
+		return style.comment
+	}
+}
+
+// check returns a description of each way the header block deviates from the format
+func (f Format) check(lines [][]byte, block headerBlock, style *languageStyle) []string {
+	problems := []string{}
+	header := lines[block.start:block.end]
+
+	if f.Banner != "" {
+		banner := f.bannerLine(style)
+		if string(bytes.TrimRight(header[0], " ")) != banner || string(bytes.TrimRight(header[len(header)-1], " ")) != banner {
+			problems = append(problems, fmt.Sprintf("expected banner %q above and below the license", banner))
+		}
+	}
+
+	blank := f.blankLine(style)
+	for i, line := range header {
+		if len(bytes.TrimSpace(bytes.TrimPrefix(bytes.TrimSpace(line), []byte(style.comment)))) == 0 && string(line) != blank {
+			problems = append(problems, fmt.Sprintf("line %d: expected blank license line as %q, got %q", block.start+i+1, blank, line))
+		}
+	}
+
+	after := skipBlank(lines, block.end) - block.end
+	if block.end+after < len(lines) && after != f.BlankLinesAfter {
+		problems = append(problems, fmt.Sprintf("expected %d blank line(s) after the license, got %d", f.BlankLinesAfter, after))
+	}
+	return problems
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+func TestFormat_render(t *testing.T) {
+	text := "Copyright 2019 Test\n\nLicensed under the Apache License, Version 2.0\n\n    http://www.apache.org/licenses/LICENSE-2.0\n"
+	tests := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{
+			name:   "default",
+			format: DefaultFormat(),
+			want:   "# Copyright 2019 Test\n#\n# Licensed under the Apache License, Version 2.0\n#\n#     http://www.apache.org/licenses/LICENSE-2.0\n",
+		},
+		{
+			name:   "banner and empty blank lines",
+			format: Format{BlankLine: BlankLineEmpty, Banner: "----"},
+			want:   "# ----\n# Copyright 2019 Test\n\n# Licensed under the Apache License, Version 2.0\n\n#     http://www.apache.org/licenses/LICENSE-2.0\n# ----\n",
+		},
+		{
+			name:   "wrapped leaves indented urls alone",
+			format: Format{BlankLine: BlankLineSpace, Width: 30},
+			want:   "# Copyright 2019 Test\n# \n# Licensed under the Apache\n# License, Version 2.0\n# \n#     http://www.apache.org/licenses/LICENSE-2.0\n",
+		},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.format.render(strings.NewReader(text), commentStyles["shell"])
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestFormat_render_reflowsParagraphs(t *testing.T) {
+	got := Format{BlankLine: BlankLineBare, Width: 60}.render(license.NewApache20(2019, "Test").Reader(), commentStyles["golang"])
+	want := ""
+	for _, line := range []string{
+		"Copyright 2019 Test",
+		"",
+		"Licensed under the Apache License, Version 2.0 (the",
+		"\"License\"); you may not use this file except in",
+		"compliance with the License. You may obtain a copy of the",
+		"License at",
+		"",
+		"    http://www.apache.org/licenses/LICENSE-2.0",
+		"",
+		"Unless required by applicable law or agreed to in",
+		"writing, software distributed under the License is",
+		"distributed on an \"AS IS\" BASIS, WITHOUT WARRANTIES OR",
+		"CONDITIONS OF ANY KIND, either express or implied. See",
+		"the License for the specific language governing",
+		"permissions and limitations under the License.",
+	} {
+		want += strings.TrimRight("// "+line, " ") + "\n"
+	}
+	assert.Equal(t, want, string(got))
+
+	// Copyright notices aren't joined with each other
+	got = Format{BlankLine: BlankLineBare, Width: 60}.render(strings.NewReader("Copyright 2019 A\nCopyright 2019 B\n"), commentStyles["shell"])
+	assert.Equal(t, "# Copyright 2019 A\n# Copyright 2019 B\n", string(got))
+}
+
+func TestFormat_check(t *testing.T) {
+	contents := "# ----\n# Copyright 2019 Test\n#\n# Licensed under the Apache License, Version 2.0\n# ----\n\necho hi\n"
+	lines := splitLines([]byte(contents))
+	block := headerBlock{start: 0, end: 5}
+
+	assert.Empty(t, Format{BlankLine: BlankLineBare, Banner: "----", BlankLinesAfter: 1}.check(lines, block, commentStyles["shell"]))
+	assert.Len(t, Format{BlankLine: BlankLineSpace, Banner: "====", BlankLinesAfter: 2}.check(lines, block, commentStyles["shell"]), 3)
+}
@@ -0,0 +1,325 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// Placement describes where in a file the license header is allowed to be.
+type Placement struct {
+	// MaxLine is the last line (counting from one) the header may start on.
+	MaxLine int
+
+	// Prologues match lines that may come before the header, such as shebangs.
+	Prologues []*regexp.Regexp
+}
+
+// DefaultPlacement requires the header to start within the first 20 lines,
+// preceded by nothing other than a shebang or an encoding declaration.
+func DefaultPlacement() Placement {
+	return Placement{
+		MaxLine: 20,
+		Prologues: []*regexp.Regexp{
+			regexp.MustCompile(`^#!`),
+			regexp.MustCompile(`^#.*coding[:=]`),
+		},
+	}
+}
+
+// isPrologue returns true if the line is allowed to precede the header
+func (p Placement) isPrologue(line []byte) bool {
+	for _, re := range p.Prologues {
+		if re.Match(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// prologueLen returns the number of leading prologue lines
+func (p Placement) prologueLen(lines [][]byte) int {
+	n := 0
+	for n < len(lines) && p.isPrologue(lines[n]) {
+		n++
+	}
+	return n
+}
+
+type headerStatus int
+
+const (
+	headerMissing headerStatus = iota
+	headerOK
+	headerMisplaced
+	headerDuplicate
+)
+
+// headerBlock is a run of comment lines holding a license header.
+// start is inclusive and end is exclusive, both are zero based line indexes.
+type headerBlock struct {
+	start, end int
+}
+
+// inspection is the result of looking for license headers in a file
+type inspection struct {
+	lines  [][]byte
+	blocks []headerBlock
+	status headerStatus
+}
+
+// layout is everything needed to find and write a header in a particular file
+type layout struct {
+	style     *languageStyle
+	format    Format
+	placement Placement
+
+	// gaps is the number of blank lines a header block may span, these are only
+	// uncommented when the format leaves blank license lines empty
+	gaps int
+}
+
+// nearMiss is a comment block that looks like the license but isn't quite right
+type nearMiss struct {
+	block      headerBlock
+	comparison license.Comparison
+}
+
+// inspect finds every license header in contents and checks the first one
+// against the placement policy.
+func inspect(contents []byte, handler license.Handler, l layout) inspection {
+	result := inspection{lines: splitLines(contents)}
+	prologue := l.placement.prologueLen(result.lines)
+	result.blocks = findHeaders(result.lines, handler, l, prologue)
+
+	switch {
+	case len(result.blocks) > 1:
+		result.status = headerDuplicate
+	case len(result.blocks) == 1:
+		result.status = headerOK
+		first := result.blocks[0]
+		if first.start >= l.placement.MaxLine || first.start != skipBlank(result.lines, prologue) {
+			result.status = headerMisplaced
+		}
+	case handler.IsPresent(bytes.NewReader(contents)):
+		// The license is there but not in this language's comment style (e.g. a block comment),
+		// we can't reason about where it is so leave it be.
+		result.status = headerOK
+	default:
+		result.status = headerMissing
+	}
+	return result
+}
+
+// closestHeader returns the comment block that best matches the license, if the handler is able to compare them.
+// Only blocks that score at least threshold are considered.
+func closestHeader(lines [][]byte, handler license.Handler, threshold float64, l layout) *nearMiss {
+	comparer, ok := handler.(license.Comparer)
+	if !ok {
+		return nil
+	}
+	var best *nearMiss
+	for _, block := range commentBlocks(lines, l, 0) {
+		comparison := comparer.Compare(string(uncomment(lines[block.start:block.end], l.style)))
+		if comparison.Score >= threshold && (best == nil || comparison.Score > best.comparison.Score) {
+			best = &nearMiss{block: block, comparison: comparison}
+		}
+	}
+	return best
+}
+
+// findHeaders returns the comment blocks the handler recognises as a license among the leading ones, those
+// before the first line of code after the prologue. Later comments may be in string literals or docs that
+// quote a license, so they're only considered if there's no leading header, and then only the first of them.
+func findHeaders(lines [][]byte, handler license.Handler, l layout, from int) []headerBlock {
+	blocks := []headerBlock{}
+	next := skipBlank(lines, from)
+	for _, block := range commentBlocks(lines, l, from) {
+		leading := block.start == next
+		if leading {
+			next = skipBlank(lines, block.end)
+		}
+		if !leading && len(blocks) > 0 {
+			break
+		}
+		if handler.IsPresent(bytes.NewReader(uncomment(lines[block.start:block.end], l.style))) {
+			blocks = append(blocks, block)
+			if !leading {
+				break
+			}
+		}
+	}
+	return blocks
+}
+
+// commentBlocks returns every run of consecutive comment lines starting at or after from.
+// Up to l.gaps single blank lines are allowed within a run.
+func commentBlocks(lines [][]byte, l layout, from int) []headerBlock {
+	blocks := []headerBlock{}
+	for i := from; i < len(lines); i++ {
+		if !isComment(lines[i], l.style) {
+			continue
+		}
+		start, gaps := i, 0
+		for i < len(lines) {
+			if isComment(lines[i], l.style) {
+				i++
+				continue
+			}
+			if gaps < l.gaps && len(bytes.TrimSpace(lines[i])) == 0 && i+1 < len(lines) && isComment(lines[i+1], l.style) {
+				gaps++
+				i++
+				continue
+			}
+			break
+		}
+		blocks = append(blocks, headerBlock{start: start, end: i})
+	}
+	return blocks
+}
+
+// identifier is an SPDX-License-Identifier found in a header
+type identifier struct {
+	line       int
+	expression string
+}
+
+// findIdentifiers returns every SPDX-License-Identifier in comments starting within the header's allowed lines
+func findIdentifiers(lines [][]byte, l layout) []identifier {
+	ids := []identifier{}
+	for _, block := range commentBlocks(lines, l, 0) {
+		if block.start >= l.placement.MaxLine {
+			break
+		}
+		for i := block.start; i < block.end; i++ {
+			if expression, ok := license.FindIdentifier(bytes.NewReader(lines[i])); ok {
+				ids = append(ids, identifier{line: i, expression: expression})
+			}
+		}
+	}
+	return ids
+}
+
+// uncomment strips the comment prefix, and the space following it, from each line
+func uncomment(lines [][]byte, style *languageStyle) []byte {
+	buf := bytes.Buffer{}
+	for _, line := range lines {
+		line = bytes.TrimPrefix(bytes.TrimSpace(line), []byte(style.comment))
+		buf.Write(bytes.TrimPrefix(line, []byte(" ")))
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+// relocate moves the first license header to the top of the file and drops any other copies
+func relocate(in inspection, l layout) []byte {
+	first := in.blocks[0]
+	header := joinLines(in.lines[first.start:first.end])
+	return merge(header, joinLines(removeHeaders(in)), l)
+}
+
+// strip removes every license header along with the blank lines separating it from the rest of the file.
+// Prologue lines are kept, without the blank line merge puts between them and the header.
+func strip(in inspection, l layout) []byte {
+	lines := removeHeaders(in)
+	prologue := l.placement.prologueLen(in.lines)
+	if prologue > 0 && skipBlank(in.lines, prologue) == in.blocks[0].start {
+		lines = append(lines[:prologue], lines[skipBlank(lines, prologue):]...)
+	}
+	return joinLines(lines)
+}
+
+// removeHeaders returns the lines of the file without the header blocks and the blank lines following them
+func removeHeaders(in inspection) [][]byte {
+	remaining := [][]byte{}
+	next := 0
+	for _, block := range in.blocks {
+		remaining = append(remaining, in.lines[next:block.start]...)
+		// Take the separating blank lines with the header
+		next = skipBlank(in.lines, block.end)
+	}
+	if next >= len(in.lines) {
+		// The last header ended the file, don't leave the blank line that preceded it dangling
+		for len(remaining) > 0 && len(bytes.TrimSpace(remaining[len(remaining)-1])) == 0 {
+			remaining = remaining[:len(remaining)-1]
+		}
+	}
+	return append(remaining, in.lines[next:]...)
+}
+
+// merge writes the license after any prologue lines (e.g. #!) and before the rest of the file
+func merge(license, file []byte, l layout) []byte {
+	result := bytes.NewBuffer([]byte{})
+	lines := splitLines(file)
+	if len(lines) == 0 {
+		return result.Bytes()
+	}
+	prologue := l.placement.prologueLen(lines)
+	for _, line := range lines[:prologue] {
+		result.Write(line)
+		result.WriteString("\n")
+	}
+	if prologue > 0 {
+		result.WriteString("\n")
+	}
+	result.Write(license)
+	rest := lines[skipBlank(lines, prologue):]
+	if len(rest) > 0 {
+		result.WriteString(strings.Repeat("\n", l.format.BlankLinesAfter))
+	}
+	result.Write(joinLines(rest))
+	return result.Bytes()
+}
+
+func isComment(line []byte, style *languageStyle) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(line), []byte(style.comment))
+}
+
+func skipBlank(lines [][]byte, from int) int {
+	for from < len(lines) && len(bytes.TrimSpace(lines[from])) == 0 {
+		from++
+	}
+	return from
+}
+
+func splitLines(contents []byte) [][]byte {
+	lines := [][]byte{}
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		lines = append(lines, copyLine(scanner.Bytes()))
+	}
+	return lines
+}
+
+func joinLines(lines [][]byte) []byte {
+	buf := bytes.Buffer{}
+	for _, line := range lines {
+		buf.Write(line)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+// copyLine is needed because the scanner reuses its buffer between calls to Scan
+func copyLine(in []byte) []byte {
+	tmp := make([]byte, len(in))
+	copy(tmp, in)
+	return tmp
+}
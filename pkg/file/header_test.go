@@ -0,0 +1,98 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+const testHeader = "# Copyright 2019 Test\n# Licensed under the Apache License, Version 2.0\n"
+
+func testLayout() layout {
+	return layout{style: commentStyles["shell"], format: DefaultFormat(), placement: DefaultPlacement()}
+}
+
+func Test_inspect(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		maxLine  int
+		want     headerStatus
+	}{
+		{"missing", "echo hi\n", 20, headerMissing},
+		{"first", testHeader + "\necho hi\n", 20, headerOK},
+		{"after shebang", "#!/bin/bash\n\n" + testHeader + "\necho hi\n", 20, headerOK},
+		{"after code", "echo hi\n\n" + testHeader, 20, headerMisplaced},
+		{"too far down", "\n\n\n" + testHeader, 3, headerMisplaced},
+		{"duplicated", testHeader + "\n" + testHeader + "\necho hi\n", 20, headerDuplicate},
+		{"license in a string after code", testHeader + "\necho hi\nnotice='\n" + testHeader + "'\n", 20, headerOK},
+	}
+	handler := license.NewApache20(0, "")
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			l := testLayout()
+			l.placement.MaxLine = tc.maxLine
+			got := inspect([]byte(tc.contents), handler, l)
+			assert.Equal(t, tc.want, got.status)
+		})
+	}
+}
+
+func Test_relocate(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     string
+	}{
+		{"after code", "echo hi\n\n" + testHeader, testHeader + "\necho hi\n"},
+		{"after code with shebang", "#!/bin/bash\necho hi\n\n" + testHeader, "#!/bin/bash\n\n" + testHeader + "\necho hi\n"},
+		{"duplicated", testHeader + "\n" + testHeader + "\necho hi\n", testHeader + "\necho hi\n"},
+	}
+	handler := license.NewApache20(0, "")
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			in := inspect([]byte(tc.contents), handler, testLayout())
+			assert.Equal(t, tc.want, string(relocate(in, testLayout())))
+		})
+	}
+}
+
+func Test_strip(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     string
+	}{
+		{"first", testHeader + "\necho hi\n", "echo hi\n"},
+		{"after shebang", "#!/bin/bash\n\n" + testHeader + "\necho hi\n", "#!/bin/bash\necho hi\n"},
+		{"after code", "echo hi\n\n" + testHeader, "echo hi\n"},
+		{"duplicated", testHeader + "\n" + testHeader + "\necho hi\n", "echo hi\n"},
+		{"only header", testHeader, ""},
+	}
+	handler := license.NewApache20(0, "")
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			in := inspect([]byte(tc.contents), handler, testLayout())
+			assert.Equal(t, tc.want, string(strip(in, testLayout())))
+		})
+	}
+}
@@ -14,8 +14,13 @@
 
 package file
 
-// Licenser is responsible for applying and verifying the presence of licenses
+import "github.com/liamawhite/licenser/pkg/license"
+
+// Licenser is responsible for applying, verifying the presence of and removing licenses
 type Licenser interface {
 	Apply(path string, dryRun bool) bool
 	Verify(path string, _ bool) bool
+	Relicense(from license.Handler, path string, dryRun bool) bool
+	Remove(path string, dryRun bool) bool
+	UpdateYear(path string, replace, dryRun bool) bool
 }
@@ -0,0 +1,125 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// LicenseRule is the license expected in some of the files in a tree
+type LicenseRule struct {
+	// Handler is the license, nil to keep the default
+	Handler license.Handler
+
+	// Owner, if set, is named in the header in place of the default owner
+	Owner string
+
+	// Skip leaves the files alone
+	Skip bool
+}
+
+// LicenseStrategy returns the rule for the file at path, or nil if the default license applies
+type LicenseStrategy func(path string) *LicenseRule
+
+// FirstLicense tries each strategy in turn and uses the first rule found
+func FirstLicense(strategies ...LicenseStrategy) LicenseStrategy {
+	return func(path string) *LicenseRule {
+		for _, strategy := range strategies {
+			if strategy == nil {
+				continue
+			}
+			if rule := strategy(path); rule != nil {
+				return rule
+			}
+		}
+		return nil
+	}
+}
+
+// LicenseMatch decides which rule applies to a file matched by several
+type LicenseMatch string
+
+const (
+	// FirstMatch uses the first rule matching the file
+	FirstMatch LicenseMatch = "first"
+	// MostSpecificMatch uses the matching rule with the narrowest pattern, the first of them if they tie
+	MostSpecificMatch LicenseMatch = "specific"
+)
+
+// ParseLicenseMatch parses the name of a LicenseMatch, defaulting to FirstMatch
+func ParseLicenseMatch(s string) (LicenseMatch, error) {
+	switch LicenseMatch(s) {
+	case "", FirstMatch:
+		return FirstMatch, nil
+	case MostSpecificMatch:
+		return MostSpecificMatch, nil
+	}
+	return "", fmt.Errorf("unknown license match %q, must be %q or %q", s, FirstMatch, MostSpecificMatch)
+}
+
+// PathLicenses maps gitignore style path patterns to license rules
+type PathLicenses struct {
+	base  string
+	match LicenseMatch
+	rules []pathLicense
+}
+
+type pathLicense struct {
+	patterns []pathPattern
+	rule     LicenseRule
+}
+
+// NewPathLicenses creates an empty mapping whose patterns are relative to base
+func NewPathLicenses(base string, match LicenseMatch) (*PathLicenses, error) {
+	abs, err := filepath.Abs(base)
+	if err != nil {
+		return nil, err
+	}
+	return &PathLicenses{base: abs, match: match}, nil
+}
+
+// Add maps the patterns to the rule. Earlier rules take precedence over later ones.
+func (p *PathLicenses) Add(patterns []string, rule LicenseRule) {
+	l := pathLicense{rule: rule}
+	for _, pattern := range patterns {
+		l.patterns = append(l.patterns, newPathPattern(pattern, p.base))
+	}
+	p.rules = append(p.rules, l)
+}
+
+// Rule returns the rule for the file at path, or nil if no pattern matches it
+func (p *PathLicenses) Rule(path string) *LicenseRule {
+	rel, ok := relativeTo(p.base, path)
+	if !ok {
+		return nil
+	}
+	var result *LicenseRule
+	best := -1
+	for i := range p.rules {
+		for _, pattern := range p.rules[i].patterns {
+			if !pattern.matches(rel) || pattern.specificity() <= best {
+				continue
+			}
+			if p.match == FirstMatch {
+				return &p.rules[i].rule
+			}
+			result, best = &p.rules[i].rule, pattern.specificity()
+		}
+	}
+	return result
+}
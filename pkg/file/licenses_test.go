@@ -0,0 +1,73 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+func TestPathLicenses_Rule(t *testing.T) {
+	mit, cc := license.NewSPDX("MIT", 2019, ""), license.NewSPDX("CC-BY-4.0", 2019, "")
+	tests := []struct {
+		path  string
+		first license.Handler
+		most  license.Handler
+		skip  bool
+	}{
+		{"/repo/main.go", nil, nil, false},
+		{"/repo/sdk/js/index.js", mit, mit, false},
+		{"/repo/sdk/js/docs/api.js", mit, cc, false},
+		{"/repo/docs/guide.js", cc, cc, false},
+		{"/repo/enterprise/billing.go", nil, nil, true},
+		{"/elsewhere/sdk/js/index.js", nil, nil, false},
+	}
+	for _, match := range []LicenseMatch{FirstMatch, MostSpecificMatch} {
+		licenses, err := NewPathLicenses("/repo", match)
+		assert.NoError(t, err)
+		licenses.Add([]string{"sdk/js/"}, LicenseRule{Handler: mit})
+		licenses.Add([]string{"docs/", "sdk/js/docs/"}, LicenseRule{Handler: cc})
+		licenses.Add([]string{"/enterprise"}, LicenseRule{Skip: true})
+		for _, tt := range tests {
+			tc := tt
+			t.Run(string(match)+" "+tc.path, func(t *testing.T) {
+				want := tc.first
+				if match == MostSpecificMatch {
+					want = tc.most
+				}
+				got := licenses.Rule(tc.path)
+				switch {
+				case tc.skip:
+					assert.True(t, got != nil && got.Skip)
+				case want == nil:
+					assert.Nil(t, got)
+				default:
+					assert.Equal(t, want, got.Handler)
+				}
+			})
+		}
+	}
+}
+
+func TestParseLicenseMatch(t *testing.T) {
+	got, err := ParseLicenseMatch("")
+	assert.NoError(t, err)
+	assert.Equal(t, FirstMatch, got)
+	_, err = ParseLicenseMatch("longest")
+	assert.Error(t, err)
+}
@@ -15,117 +15,464 @@
 package file
 
 import (
-	"bufio"
-	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"text/template"
 
 	"github.com/liamawhite/licenser/pkg/license"
 )
 
 // New returns a new file Mutator
-func New(license license.Handler) *Mutator {
-	return &Mutator{license: license}
+func New(handler license.Handler, opts ...Option) *Mutator {
+	m := &Mutator{
+		license:             handler,
+		placement:           DefaultPlacement(),
+		format:              DefaultFormat(),
+		languageFormats:     map[string]Format{},
+		languageLicenses:    map[string]license.Handler{},
+		vars:                map[string]string{},
+		similarityThreshold: DefaultSimilarityThreshold,
+		history:             NewGitHistory(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Option configures optional Mutator behaviour
+type Option func(*Mutator)
+
+// WithPlacement sets the policy for where the license header may appear
+func WithPlacement(placement Placement) Option {
+	return func(m *Mutator) {
+		m.placement = placement
+	}
+}
+
+// WithFixPlacement makes Apply move misplaced headers to the top of the file and remove duplicates
+func WithFixPlacement(fix bool) Option {
+	return func(m *Mutator) {
+		m.fixPlacement = fix
+	}
+}
+
+// WithSimilarityThreshold sets how closely, between 0 and 1, a header must match the license
+// for Verify to report it as a near miss rather than missing
+func WithSimilarityThreshold(threshold float64) Option {
+	return func(m *Mutator) {
+		m.similarityThreshold = threshold
+	}
+}
+
+// WithFormat sets how the license header is laid out in languages without their own format
+func WithFormat(format Format) Option {
+	return func(m *Mutator) {
+		m.format = format
+	}
+}
+
+// WithLanguageFormat sets how the license header is laid out in the named language, e.g. "golang"
+func WithLanguageFormat(language string, format Format) Option {
+	return func(m *Mutator) {
+		m.languageFormats[language] = format
+	}
+}
+
+// WithProjectName sets the project name license templates can use as .ProjectName, by default the
+// name of the current directory
+func WithProjectName(name string) Option {
+	return func(m *Mutator) {
+		m.projectName = name
+	}
+}
+
+// WithTemplateVars adds user defined values license templates can use as .Vars.key, replacing any
+// already set with the same keys
+func WithTemplateVars(vars map[string]string) Option {
+	return func(m *Mutator) {
+		for k, v := range vars {
+			m.vars[k] = v
+		}
+	}
+}
+
+// WithLanguageLicense sets the license written into files in the named language, e.g. a short SPDX header
+// for "yaml", in place of the default license
+func WithLanguageLicense(language string, handler license.Handler) Option {
+	return func(m *Mutator) {
+		m.languageLicenses[language] = handler
+	}
+}
+
+// WithStrict makes Verify also check the header is laid out according to its format
+func WithStrict(strict bool) Option {
+	return func(m *Mutator) {
+		m.strict = strict
+	}
+}
+
+// WithYearStrategy sets how the copyright year written into each file is chosen.
+// It only applies to licenses whose year can be changed per file.
+func WithYearStrategy(years YearStrategy) Option {
+	return func(m *Mutator) {
+		m.years = years
+	}
+}
+
+// WithGitHistory sets where the history of files is looked up. Pass the history given to GitYear so git is
+// only asked once per file.
+func WithGitHistory(history *GitHistory) Option {
+	return func(m *Mutator) {
+		m.history = history
+	}
+}
+
+// WithAddHolders makes Apply add the license's copyright notices to existing headers that don't name its holders
+func WithAddHolders(add bool) Option {
+	return func(m *Mutator) {
+		m.addHolders = add
+	}
+}
+
+// WithModificationNotice makes Apply leave foreign headers alone, adding the notice beneath them.
+// The notice is rendered for the owners set with WithOwners.
+func WithModificationNotice(notice *template.Template) Option {
+	return func(m *Mutator) {
+		m.notice = notice
+	}
+}
+
+// WithHolderAliases sets the aliases under which copyright holders are also known
+func WithHolderAliases(holders *license.Holders) Option {
+	return func(m *Mutator) {
+		m.holders = holders
+	}
+}
+
+// WithCanonicalHolders makes Apply rewrite copyright notices naming one of the owners, set with WithOwners,
+// in another form to name it exactly as given
+func WithCanonicalHolders(canonical bool) Option {
+	return func(m *Mutator) {
+		m.canonicalHolders = canonical
+	}
+}
+
+// WithOwners makes Verify check every copyright notice in the header names one of the owners
+func WithOwners(owners ...string) Option {
+	return func(m *Mutator) {
+		m.owners = owners
+	}
+}
+
+// WithOwnerStrategy sets how the copyright owner named in each file is chosen, for when it varies
+// across the tree. It replaces any owners set with WithOwners and only applies to licenses whose owner
+// can be changed per file.
+func WithOwnerStrategy(owners OwnerStrategy) Option {
+	return func(m *Mutator) {
+		m.ownerStrategy = owners
+	}
+}
+
+// WithLicenseStrategy sets how the license expected in each file is chosen, for trees mixing licenses.
+// Files it has no rule for get the default license.
+func WithLicenseStrategy(licenses LicenseStrategy) Option {
+	return func(m *Mutator) {
+		m.licenses = licenses
+	}
+}
+
+// WithValidYears makes Verify check every copyright year in the header falls within the range
+func WithValidYears(years license.YearRange) Option {
+	return func(m *Mutator) {
+		m.validYears = &years
+	}
+}
+
+// WithYearHistory makes Verify check copyright years aren't after the current year
+// or before the file was first committed
+func WithYearHistory(current int) Option {
+	return func(m *Mutator) {
+		m.currentYear = current
+	}
 }
 
 var _ Licenser = &Mutator{}
 
 // Mutator mutates files
 type Mutator struct {
-	license license.Handler
+	license  license.Handler
+	licenses LicenseStrategy
+
+	placement    Placement
+	fixPlacement bool
+	addHolders   bool
+	notice       *template.Template
+
+	similarityThreshold float64
+
+	format           Format
+	languageFormats  map[string]Format
+	languageLicenses map[string]license.Handler
+	strict           bool
+
+	years   YearStrategy
+	history *GitHistory
+
+	projectName string
+	vars        map[string]string
+
+	owners           []string
+	ownerStrategy    OwnerStrategy
+	holders          *license.Holders
+	canonicalHolders bool
+	validYears       *license.YearRange
+	currentYear      int
 }
 
-// Apply the license to the path passed or print to stdout if dryRun
+// DefaultSimilarityThreshold is the lowest score at which a header is considered a near miss of the license
+const DefaultSimilarityThreshold = 0.75
+
+// Apply the license to the path passed or print to stdout if dryRun.
+// Headers belonging to someone else, with a different license or owner, are left as they are.
 func (m *Mutator) Apply(path string, dryRun bool) bool {
 	// If we can't detect language skip (return true)
-	styled := m.styledLicense(path)
-	if styled == nil {
+	style := identifyLanguageStyle(path)
+	if style == nil {
 		return true
 	}
+	m, err := m.forFile(path, style)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	if m == nil {
+		return true
+	}
+	handler := m.handler(path, style)
+	if _, err := license.Render(handler); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error rendering license for %v: %v\n", path, err)
+		return false
+	}
 	contents := getFileContents(path)
 	if contents == nil {
 		return false
 	}
-	if !m.license.IsPresent(bytes.NewReader(contents)) {
-		newContents := merge(styled, contents)
-		if dryRun {
-			fmt.Printf("%s\n", newContents)
-		} else if err := os.WriteFile(path, newContents, 0644); err != nil { // nolint: gosec
-			_, _ = fmt.Fprintf(os.Stderr, "error writing license to %v:%v", path, err)
+	l := m.layout(style)
+	in := inspect(contents, m.license, l)
+	canonicalized := false
+	if m.canonicalHolders && in.status == headerOK && len(in.blocks) > 0 {
+		if canonicalized, err = m.canonicalize(in.lines, in.blocks[0], style, handler); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "error rendering license for %v: %v\n", path, err)
+			return false
+		}
+	}
+	var newContents []byte
+	switch foreign := m.foreignHeader(in, l); {
+	case foreign != nil && m.notice != nil:
+		// Someone else's header must stay as it is, we only note our modifications beneath it
+		if newContents, err = m.addModificationNotice(path, in, *foreign, l); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "error rendering modification notice for %v: %v\n", path, err)
+			return false
+		}
+	case foreign != nil && in.status == headerMissing:
+		_, _ = fmt.Fprintf(os.Stderr, "skipping %v: it starts with someone else's header on line %d\n", path, foreign.start+1)
+		return true
+	case in.status == headerMissing:
+		newContents = merge(m.styledLicense(handler, l), contents, l)
+	case in.status == headerMisplaced || in.status == headerDuplicate:
+		if !m.fixPlacement {
+			return true
 		}
+		newContents = relocate(in, l)
+	case m.addHolders && len(in.blocks) > 0:
+		if newContents, err = m.addNotices(in, style, handler); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "error rendering license for %v: %v\n", path, err)
+			return false
+		}
+	}
+	if newContents == nil && canonicalized {
+		newContents = joinLines(in.lines)
+	}
+	if newContents == nil {
+		return true
+	}
+	if dryRun {
+		fmt.Printf("%s\n", newContents)
+	} else if err := os.WriteFile(path, newContents, 0644); err != nil { // nolint: gosec
+		_, _ = fmt.Fprintf(os.Stderr, "error writing license to %v:%v", path, err)
 	}
 	return true
 }
 
-// Verify returns true if the license is present in the file passed
-func (m *Mutator) Verify(path string, _ bool) bool {
+// Remove the license header from the path passed or print the result to stdout if dryRun
+func (m *Mutator) Remove(path string, dryRun bool) bool {
+	// If we can't detect language skip (return true)
+	style := identifyLanguageStyle(path)
+	if style == nil {
+		return true
+	}
 	contents := getFileContents(path)
 	if contents == nil {
 		return false
 	}
-	// If we can't detect language skip (return true)
-	if style := identifyLanguageStyle(path); style == nil {
+	l := m.layout(style)
+	in := inspect(contents, m.license, l)
+	if len(in.blocks) == 0 {
 		return true
 	}
-	present := m.license.IsPresent(bytes.NewReader(contents))
-	if !present {
-		_, _ = fmt.Fprintf(os.Stderr, "license missing from %v\n", path)
+	newContents := strip(in, l)
+	if dryRun {
+		fmt.Printf("%s\n", newContents)
+	} else if err := os.WriteFile(path, newContents, 0644); err != nil { // nolint: gosec
+		_, _ = fmt.Fprintf(os.Stderr, "error removing license from %v:%v", path, err)
 	}
-	return present
+	return true
 }
 
-// this should probably be cached on a per language basis
-func (m *Mutator) styledLicense(path string) []byte {
+// Verify returns true if the license is present, correctly placed and not duplicated in the file passed.
+// In strict mode the header must also be laid out according to the language's format.
+// The header's copyright notices are checked against the expected owners and years, if set.
+func (m *Mutator) Verify(path string, _ bool) bool {
+	contents := getFileContents(path)
+	if contents == nil {
+		return false
+	}
+	// If we can't detect language skip (return true)
 	style := identifyLanguageStyle(path)
 	if style == nil {
-		return nil
+		return true
 	}
-	buf := bytes.NewBuffer([]byte{})
-
-	// TODO: implement block styling
-	if style.isBlock {
-
-	} else {
-		scanner := bufio.NewScanner(m.license.Reader())
-		for scanner.Scan() {
-			_, _ = buf.WriteString(style.comment)
-			if len(scanner.Bytes()) != 0 {
-				_, _ = buf.WriteString(" ")
+	m, err := m.forFile(path, style)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	if m == nil {
+		return true
+	}
+	handler := m.handler(path, style)
+	if _, err := license.Render(handler); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error rendering license for %v: %v\n", path, err)
+		return false
+	}
+	l := m.layout(style)
+	in := inspect(contents, m.license, l)
+	switch in.status {
+	case headerMissing:
+		if near := closestHeader(in.lines, handler, m.similarityThreshold, l); near != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "license header present in %v but differs (%d%% match), starting on line %d:\n%s\n",
+				path, int(near.comparison.Score*100), near.block.start+1, strings.Join(near.comparison.Differences, "\n"))
+		} else {
+			_, _ = fmt.Fprintf(os.Stderr, "license missing from %v\n", path)
+		}
+	case headerMisplaced:
+		_, _ = fmt.Fprintf(os.Stderr, "license misplaced in %v: starts on line %d, must be first and start by line %d\n",
+			path, in.blocks[0].start+1, m.placement.MaxLine)
+	case headerDuplicate:
+		_, _ = fmt.Fprintf(os.Stderr, "duplicate license in %v: found on lines %d and %d\n",
+			path, in.blocks[0].start+1, in.blocks[1].start+1)
+	case headerOK:
+		if m.strict && len(in.blocks) > 0 {
+			if problems := l.format.check(in.lines, in.blocks[0], style); len(problems) > 0 {
+				_, _ = fmt.Fprintf(os.Stderr, "license header in %v is not formatted correctly:\n  %s\n", path, strings.Join(problems, "\n  "))
+				return false
 			}
-			_, _ = buf.Write(scanner.Bytes())
-			_, _ = buf.WriteString("\n")
 		}
 	}
-	return buf.Bytes()
+	valid := true
+	if len(in.blocks) > 0 {
+		for _, problem := range m.checkCopyrights(path, in.lines, in.blocks[0], style) {
+			_, _ = fmt.Fprintln(os.Stderr, problem)
+			valid = false
+		}
+	}
+	for _, id := range findIdentifiers(in.lines, l) {
+		for _, problem := range license.ValidateExpression(id.expression) {
+			_, _ = fmt.Fprintf(os.Stderr, "invalid SPDX-License-Identifier in %v on line %d: %v\n", path, id.line+1, problem)
+			valid = false
+		}
+	}
+	return in.status == headerOK && valid
 }
 
-// this is also pretty horrible but does the job
-func merge(license, file []byte) []byte {
-	result := bytes.NewBuffer([]byte{})
-	fileScanner := bufio.NewScanner(bytes.NewReader(file))
-	for fileScanner.Scan() {
-		// If there's a #! preserve it
-		if strings.Contains(fileScanner.Text(), "#!") {
-			result.Write(fileScanner.Bytes())
-			result.WriteString("\n\n")
-			result.Write(license)
-		} else {
-			result.Write(license)
-			result.WriteString("\n")
-			result.Write(fileScanner.Bytes())
-			result.WriteString("\n")
+// layout gathers the style, format and placement used for a file in the given language
+func (m *Mutator) layout(style *languageStyle) layout {
+	l := layout{style: style, format: m.format, placement: m.placement}
+	if format, ok := m.languageFormats[style.name]; ok {
+		l.format = format
+	}
+	if l.format.BlankLine == BlankLineEmpty {
+		for _, line := range splitLines(m.styledLicense(m.license, l)) {
+			if len(line) == 0 {
+				l.gaps++
+			}
 		}
+	}
+	return l
+}
 
-		// Now that we've written the license just dump the rest
-		for fileScanner.Scan() {
-			result.Write(fileScanner.Bytes())
-			result.WriteString("\n")
+// this should probably be cached on a per language basis
+func (m *Mutator) styledLicense(handler license.Handler, l layout) []byte {
+	// TODO: implement block styling
+	if l.style.isBlock {
+		return []byte{}
+	}
+	return l.format.render(handler.Reader(), l.style)
+}
+
+// handler returns the license to write into the file at path, dated according to the year strategy
+// and rendered for the file if the license's template can use its details
+func (m *Mutator) handler(path string, style *languageStyle) license.Handler {
+	h := m.license
+	if dated, ok := h.(license.Dated); ok && m.years != nil {
+		h = dated.WithYear(m.years(path))
+	}
+	if contextual, ok := h.(license.Contextual); ok {
+		h = contextual.WithContext(m.fileContext(path, style))
+	}
+	return h
+}
+
+// forFile returns a copy of the mutator with the license and owner chosen for the file at path by the
+// license strategy, or failing that its language, and the owner strategy. It returns nil if the license
+// strategy skips the file, and an error if there's an owner strategy but neither it nor the license rule
+// names an owner for the file.
+func (m *Mutator) forFile(path string, style *languageStyle) (*Mutator, error) {
+	if m.licenses == nil && m.ownerStrategy == nil && len(m.languageLicenses) == 0 {
+		return m, nil
+	}
+	c := *m
+	if handler, ok := m.languageLicenses[style.name]; ok {
+		c.license = handler
+	}
+	owner := ""
+	if m.licenses != nil {
+		if rule := m.licenses(path); rule != nil {
+			if rule.Skip {
+				return nil, nil
+			}
+			if rule.Handler != nil {
+				c.license = rule.Handler
+			}
+			owner = rule.Owner
+		}
+	}
+	if owner == "" && m.ownerStrategy != nil {
+		if owner = m.ownerStrategy(path); owner == "" {
+			return nil, fmt.Errorf("no copyright owner for %v", path)
+		}
+	}
+	if owner != "" {
+		if owned, ok := c.license.(license.Owned); ok {
+			c.license = owned.WithOwners(owner)
 		}
+		c.owners = []string{owner}
 	}
-	return result.Bytes()
+	return &c, nil
 }
 
 // This function has the potential to become an unwiedly mess, consider rethinking.
@@ -16,9 +16,15 @@ package file
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
 )
 
 func Test_identifyLanguageStyle(t *testing.T) {
@@ -111,3 +117,99 @@ func Test_identifyLanguageStyle(t *testing.T) {
 		})
 	}
 }
+
+func TestMutator_Apply_LanguageLicense(t *testing.T) {
+	dir, err := ioutil.TempDir("", "licenser")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	golang, yaml := filepath.Join(dir, "main.go"), filepath.Join(dir, "config.yaml")
+	assert.NoError(t, ioutil.WriteFile(golang, []byte("package main\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(yaml, []byte("a: 1\n"), 0644))
+
+	m := New(license.NewApache20(2019, "Test"), WithLanguageLicense("yaml", license.NewSPDX("Apache-2.0", 2019, "Test")))
+	for _, path := range []string{golang, yaml} {
+		assert.True(t, m.Apply(path, false))
+		assert.True(t, m.Verify(path, false))
+	}
+	written, _ := ioutil.ReadFile(yaml)
+	assert.Equal(t, "# SPDX-FileCopyrightText: 2019 Test\n# SPDX-License-Identifier: Apache-2.0\n\na: 1\n", string(written))
+	written, _ = ioutil.ReadFile(golang)
+	assert.Contains(t, string(written), "// Licensed under the Apache License, Version 2.0")
+}
+
+func TestMutator_Apply_RenderError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "licenser")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "main.go")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("package main\n"), 0644))
+
+	h, err := license.FromTemplateString("Copyright {{.Year}} {{.Onwer}}\n\nMy License\n", "My License", 2019, "Test")
+	assert.NoError(t, err)
+	m := New(h)
+	assert.False(t, m.Apply(path, false))
+	assert.False(t, m.Verify(path, false))
+	written, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "package main\n", string(written))
+}
+
+func TestMutator_Verify_BSD(t *testing.T) {
+	dir, err := ioutil.TempDir("", "licenser")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	bsd2, _ := license.LookupBuiltin("BSD-2-Clause")
+	bsd3, _ := license.LookupBuiltin("BSD-3-Clause")
+	two, three := filepath.Join(dir, "two.go"), filepath.Join(dir, "three.go")
+	for path, b := range map[string]license.Builtin{two: bsd2, three: bsd3} {
+		assert.NoError(t, ioutil.WriteFile(path, []byte("package main\n"), 0644))
+		assert.True(t, New(b.Handler(2019, "Test")).Apply(path, false))
+	}
+
+	// BSD-3-Clause contains all of BSD-2-Clause's text
+	assert.False(t, New(bsd2.Handler(2019, "Test")).Verify(three, false))
+	assert.False(t, New(bsd3.Handler(2019, "Test")).Verify(two, false))
+	assert.True(t, New(bsd2.Handler(2019, "Test")).Verify(two, false))
+	assert.True(t, New(bsd3.Handler(2019, "Test")).Verify(three, false))
+}
+
+func TestMutator_UpdateYear(t *testing.T) {
+	dir, commitFile := gitRepo(t)
+	modified := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	policies := map[string]YearStrategy{
+		"always":   FixedYear(license.Year(2026)),
+		"modified": ModifiedYear(2026),
+		"git":      GitYear(NewGitHistory(), 2026, true),
+	}
+	tests := []struct {
+		policy  string
+		years   string
+		replace bool
+		want    string
+	}{
+		{"always", "2019", false, "2019-2026"},
+		{"always", "2019-2021", false, "2019-2026"},
+		{"always", "2019-2026", false, "2019-2026"},
+		{"always", "2019", true, "2026"},
+		{"modified", "2019", false, "2019-2024"},
+		{"modified", "2019-2021", false, "2019-2024"},
+		{"modified", "2019-2021", true, "2024"},
+		{"git", "2019", false, "2019-2023"},
+		{"git", "2019-2021", false, "2019-2023"},
+		{"git", "2019-2021", true, "2023"},
+	}
+	for i, tt := range tests {
+		tc := tt
+		name := fmt.Sprintf("%s %s replace %v", tc.policy, tc.years, tc.replace)
+		t.Run(name, func(t *testing.T) {
+			file := fmt.Sprintf("%d.sh", i)
+			commitFile(file, "# Copyright "+tc.years+" Test\n# Licensed under the Apache License, Version 2.0\n\necho hi\n", "Ada", 2023)
+			path := filepath.Join(dir, file)
+			assert.NoError(t, os.Chtimes(path, modified, modified))
+
+			m := New(license.NewApache20(2026, "Test"), WithYearStrategy(policies[tc.policy]))
+			assert.True(t, m.UpdateYear(path, tc.replace, false))
+			written, _ := ioutil.ReadFile(path)
+			assert.Equal(t, "# Copyright "+tc.want+" Test\n# Licensed under the Apache License, Version 2.0\n\necho hi\n", string(written))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// LicenseFileNames are the names of files declaring the license of the directory they're in and everything beneath it
+var LicenseFileNames = []string{"LICENSE", "LICENSE.txt", "LICENSE.md", "LICENCE", "COPYING", "COPYING.txt", "COPYING.md"}
+
+// NearestLicenses chooses the license expected in each file from the closest license file in its directory
+// or those above it, up to but not including the root. Files only governed by the root's license file,
+// if any, get the default license.
+type NearestLicenses struct {
+	root string
+	rule func(id string) *LicenseRule
+
+	mu   sync.Mutex
+	dirs map[string]*LicenseRule
+}
+
+// NewNearestLicenses creates a strategy for the tree at root. rule is called with the license, as
+// classified by license.ClassifyLicenseFile, of each license file found and returns the rule for the
+// files it governs, or nil for the default license.
+func NewNearestLicenses(root string, rule func(id string) *LicenseRule) (*NearestLicenses, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	return &NearestLicenses{root: abs, rule: rule, dirs: map[string]*LicenseRule{}}, nil
+}
+
+// Rule returns the rule for the license file closest to path, or nil if there's none below the root
+func (n *NearestLicenses) Rule(path string) *LicenseRule {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil
+	}
+	if _, ok := relativeTo(n.root, abs); !ok {
+		return nil
+	}
+	return n.dirRule(filepath.Dir(abs))
+}
+
+// dirRule returns the rule governing dir, classifying its license file the first time it's asked
+func (n *NearestLicenses) dirRule(dir string) *LicenseRule {
+	if dir == n.root || dir == filepath.Dir(dir) {
+		return nil
+	}
+	n.mu.Lock()
+	rule, ok := n.dirs[dir]
+	n.mu.Unlock()
+	if ok {
+		return rule
+	}
+	if id, found := classifyLicenseFile(dir); found {
+		rule = n.rule(id)
+	} else {
+		rule = n.dirRule(filepath.Dir(dir))
+	}
+	n.mu.Lock()
+	n.dirs[dir] = rule
+	n.mu.Unlock()
+	return rule
+}
+
+// classifyLicenseFile classifies the first license file in dir, returning false if it has none
+func classifyLicenseFile(dir string) (string, bool) {
+	for _, name := range LicenseFileNames {
+		contents, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			return license.ClassifyLicenseFile(string(contents)), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+func TestNearestLicenses_Rule(t *testing.T) {
+	dir, err := ioutil.TempDir("", "licenser")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for path, contents := range map[string]string{
+		"LICENSE":               "Apache License\nVersion 2.0, January 2004\n",
+		"vendor/mit/COPYING":    "Copyright (c) 2019 Test\n\nPermission is hereby granted, free of charge, to any person obtaining a copy\n",
+		"vendor/custom/LICENSE": "Copyright 2019 Test\nAll rights reserved.\n",
+	} {
+		path = filepath.Join(dir, path)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	}
+
+	classified := []string{}
+	nearest, err := NewNearestLicenses(dir, func(id string) *LicenseRule {
+		classified = append(classified, id)
+		return &LicenseRule{Handler: license.NewSPDX(id, 2019, "")}
+	})
+	assert.NoError(t, err)
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"main.go", ""},
+		{"pkg/main.go", ""},
+		{"vendor/mit/mit.go", "MIT"},
+		{"vendor/mit/sub/deep/mit.go", "MIT"},
+		{"vendor/custom/custom.go", license.Unknown},
+		{"vendor/mit/other.go", "MIT"},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.path, func(t *testing.T) {
+			got := nearest.Rule(filepath.Join(dir, tc.path))
+			if tc.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tc.want, got.Handler.(*license.SPDX).Expression)
+		})
+	}
+	// Each license file is only classified once
+	assert.ElementsMatch(t, []string{"MIT", license.Unknown}, classified)
+}
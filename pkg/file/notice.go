@@ -0,0 +1,139 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// DefaultModificationNotice is the notice added beneath foreign headers if one isn't configured
+const DefaultModificationNotice = "Modifications copyright {{.Year}} {{.Owner}}"
+
+// noticeData is what modification notice templates are rendered with
+type noticeData struct {
+	Year   string
+	Owner  string
+	Owners []string
+}
+
+// yearPlaceholder stands in for the year when matching existing notices, so notices from earlier years are found
+const yearPlaceholder = "\x00"
+
+// foreignHeader returns the header at the top of the file if it belongs to someone else: a different
+// license, or this license naming none of the owners. It returns nil if the top of the file isn't a
+// header or the header is ours.
+func (m *Mutator) foreignHeader(in inspection, l layout) *headerBlock {
+	from := skipBlank(in.lines, l.placement.prologueLen(in.lines))
+	blocks := commentBlocks(in.lines, l, from)
+	if len(blocks) == 0 || blocks[0].start != from || from >= l.placement.MaxLine {
+		return nil
+	}
+	block := blocks[0]
+	notices := findCopyrights(in.lines, block, l.style)
+	if in.status == headerMissing {
+		// Comments merely mentioning a license, e.g. "checks licence keys", aren't headers
+		switch license.Classify(string(uncomment(in.lines[block.start:block.end], l.style))) {
+		case license.None, license.Unknown:
+			// Copyright notices alone are only someone else's if they name none of the owners
+			if len(notices) == 0 || m.namesOwner(notices) {
+				return nil
+			}
+		}
+		return &block
+	}
+	if len(m.owners) == 0 || len(in.blocks) == 0 || in.blocks[0].start != block.start || len(notices) == 0 {
+		return nil
+	}
+	if m.namesOwner(notices) {
+		return nil
+	}
+	return &in.blocks[0]
+}
+
+// namesOwner returns true if one of the notices names one of the owners, or one of their aliases
+func (m *Mutator) namesOwner(notices []copyrightLine) bool {
+	for _, owner := range m.owners {
+		if m.names(notices, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// addModificationNotice inserts the modification notice beneath the header block, separated by a blank
+// comment line. It returns nil if the header already has a notice from one of the owners, and an error
+// if the notice template can't be rendered.
+func (m *Mutator) addModificationNotice(path string, in inspection, block headerBlock, l layout) ([]byte, error) {
+	if m.hasModificationNotice(in.lines[block.start:block.end], l.style) {
+		return nil, nil
+	}
+	current, err := CurrentYear()
+	if err != nil {
+		return nil, err
+	}
+	year := license.Year(current)
+	if m.years != nil {
+		year = m.years(path)
+	}
+	text, err := m.renderNotice(year.String())
+	if err != nil {
+		return nil, err
+	}
+	notice := [][]byte{[]byte(l.format.blankLine(l.style))}
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		for _, wrapped := range l.format.wrap(line, l.style) {
+			notice = append(notice, []byte(strings.TrimRight(l.style.comment+" "+wrapped, " ")))
+		}
+	}
+	return joinLines(append(append(append([][]byte{}, in.lines[:block.end]...), notice...), in.lines[block.end:]...)), nil
+}
+
+// hasModificationNotice returns true if the header contains the notice, from any year
+func (m *Mutator) hasModificationNotice(header [][]byte, style *languageStyle) bool {
+	text, err := m.renderNotice(yearPlaceholder)
+	if err != nil {
+		return false
+	}
+	pattern := regexp.QuoteMeta(strings.Join(strings.Fields(text), " "))
+	pattern = strings.ReplaceAll(pattern, yearPlaceholder, `\d{4}(?:\s*(?:-|–|,)\s*\d{4})*`)
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(strings.Join(strings.Fields(string(uncomment(header, style))), " "))
+}
+
+func (m *Mutator) renderNotice(year string) (string, error) {
+	data := noticeData{Year: year, Owners: m.owners}
+	if len(m.owners) > 0 {
+		data.Owner = m.owners[0]
+	}
+	b := bytes.NewBuffer([]byte{})
+	if err := m.notice.Execute(b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// ParseModificationNotice parses a modification notice template, which may use .Year, .Owner and .Owners
+// and the license template functions
+func ParseModificationNotice(notice string) (*template.Template, error) {
+	return template.New("notice").Funcs(license.Funcs()).Parse(notice)
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+const upstreamHeader = "# Copyright 2019 Upstream Authors\n# Licensed under the Apache License, Version 2.0\n"
+
+func TestMutator_foreignHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		want     bool
+	}{
+		{"other owner", upstreamHeader + "\necho hi\n", true},
+		{"our header", "# Copyright 2019 Our Corp\n# Licensed under the Apache License, Version 2.0\n\necho hi\n", false},
+		{"other license", "# Copyright (c) 2019 Someone\n# Permission is hereby granted, free of charge\n\necho hi\n", true},
+		{"plain comment", "# says hi\necho hi\n", false},
+		{"comment mentioning license", "# Helpers for checking licence keys.\necho hi\n", false},
+		{"copyright without license", "# Copyright 2019 Someone Else\n\necho hi\n", true},
+		{"our copyright without license", "# Copyright 2019 Our Corp\n\necho hi\n", false},
+		{"our alias's copyright without license", "# Copyright 2019 Our Corporation\n\necho hi\n", false},
+		{"no header", "echo hi\n", false},
+	}
+	m := New(license.NewApache20(2026, "Our Corp"), WithOwners("Our Corp"),
+		WithHolderAliases(license.NewHolders(map[string][]string{"Our Corp": {"Our Corporation"}})))
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			l := testLayout()
+			got := m.foreignHeader(inspect([]byte(tc.contents), m.license, l), l)
+			assert.Equal(t, tc.want, got != nil)
+		})
+	}
+}
+
+func TestMutator_addModificationNotice(t *testing.T) {
+	notice, err := ParseModificationNotice(DefaultModificationNotice)
+	assert.NoError(t, err)
+	m := New(license.NewApache20(2026, "Our Corp"), WithOwners("Our Corp"), WithModificationNotice(notice),
+		WithYearStrategy(FixedYear(license.Year(2026))))
+	l := testLayout()
+
+	in := inspect([]byte(upstreamHeader+"\necho hi\n"), m.license, l)
+	got, err := m.addModificationNotice("test.sh", in, in.blocks[0], l)
+	assert.NoError(t, err)
+	want := upstreamHeader + "#\n# Modifications copyright 2026 Our Corp\n\necho hi\n"
+	assert.Equal(t, want, string(got))
+
+	// Notices from earlier years aren't added again
+	m.years = FixedYear(license.Year(2027))
+	in = inspect(got, m.license, l)
+	again, err := m.addModificationNotice("test.sh", in, *m.foreignHeader(in, l), l)
+	assert.NoError(t, err)
+	assert.Nil(t, again)
+
+	// Without a year strategy the notice carries the year of SOURCE_DATE_EPOCH
+	t.Setenv("SOURCE_DATE_EPOCH", "1577836800")
+	m.years = nil
+	in = inspect([]byte(upstreamHeader+"\necho hi\n"), m.license, l)
+	got, err = m.addModificationNotice("test.sh", in, in.blocks[0], l)
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), "# Modifications copyright 2020 Our Corp\n")
+
+	// Notices that can't be rendered are reported
+	m.notice, err = ParseModificationNotice("Modifications copyright {{.Year}} {{.Onwer}}")
+	assert.NoError(t, err)
+	m.years = FixedYear(license.Year(2026))
+	in = inspect([]byte(upstreamHeader+"\necho hi\n"), m.license, l)
+	_, err = m.addModificationNotice("test.sh", in, in.blocks[0], l)
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// DefaultOwnersFile is where owner rules are read from unless another file is configured
+const DefaultOwnersFile = ".licenser-owners"
+
+// OwnerStrategy returns the copyright owner to name in the header of the file at path, or "" if it has none
+type OwnerStrategy func(path string) string
+
+// FixedOwner names the same owner in every file
+func FixedOwner(owner string) OwnerStrategy {
+	return func(string) string {
+		return owner
+	}
+}
+
+// FirstOwner tries each strategy in turn and names the first owner found
+func FirstOwner(strategies ...OwnerStrategy) OwnerStrategy {
+	return func(path string) string {
+		for _, strategy := range strategies {
+			if strategy == nil {
+				continue
+			}
+			if owner := strategy(path); owner != "" {
+				return owner
+			}
+		}
+		return ""
+	}
+}
+
+// OwnerRules maps paths to copyright owners, like a CODEOWNERS file maps them to reviewers
+type OwnerRules struct {
+	base  string
+	rules []ownerRule
+}
+
+type ownerRule struct {
+	pattern pathPattern
+	owner   string
+}
+
+// ParseOwnerRules reads one rule per line: a gitignore style pattern followed by the owner, e.g.
+// "vendor/acme/ Acme, Inc.". Patterns are relative to base, blank lines and lines starting with # are
+// ignored. As in CODEOWNERS, the last matching rule wins.
+func ParseOwnerRules(r io.Reader, base string) (*OwnerRules, error) {
+	abs, err := filepath.Abs(base)
+	if err != nil {
+		return nil, err
+	}
+	o := &OwnerRules{base: abs}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected a pattern followed by an owner, got %q", n, line)
+		}
+		owner := strings.TrimSpace(strings.TrimPrefix(line, fields[0]))
+		o.rules = append(o.rules, ownerRule{pattern: newPathPattern(fields[0], abs), owner: owner})
+	}
+	return o, scanner.Err()
+}
+
+// LoadOwnerRules reads the rules in the file at path, whose patterns are relative to its directory
+func LoadOwnerRules(path string) (*OwnerRules, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	rules, err := ParseOwnerRules(f, filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("invalid owners file %v: %v", path, err)
+	}
+	return rules, nil
+}
+
+// Owner returns the owner of the last rule matching path, or "" if none match or path is outside the base directory
+func (o *OwnerRules) Owner(path string) string {
+	rel, ok := relativeTo(o.base, path)
+	if !ok {
+		return ""
+	}
+	for i := len(o.rules) - 1; i >= 0; i-- {
+		if o.rules[i].pattern.matches(rel) {
+			return o.rules[i].owner
+		}
+	}
+	return ""
+}
+
+var authorEmail = regexp.MustCompile(`\s*[<(][^>)]*@[^>)]*[>)]`)
+
+// ReadAuthors returns the first author listed in an AUTHORS file, without their email address.
+// Blank lines and lines starting with # are skipped.
+func ReadAuthors(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return strings.TrimSpace(authorEmail.ReplaceAllString(line, "")), nil
+	}
+	return "", scanner.Err()
+}
+
+// GitOwner returns the user.organization from the git config in dir, falling back to user.name.
+// It returns "" if neither is set.
+func GitOwner(dir string) string {
+	for _, key := range []string{"user.organization", "user.name"} {
+		cmd := exec.Command("git", "config", "--get", key)
+		cmd.Dir = dir
+		if out, err := cmd.Output(); err == nil && strings.TrimSpace(string(out)) != "" {
+			return strings.TrimSpace(string(out))
+		}
+	}
+	return ""
+}
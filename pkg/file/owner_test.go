@@ -0,0 +1,105 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+const testOwnerRules = `# Legal entities by path
+*          Liam White
+vendor/    Acme, Inc.
+/docs/*.md Docs Team
+`
+
+func TestOwnerRules_Owner(t *testing.T) {
+	rules, err := ParseOwnerRules(strings.NewReader(testOwnerRules), "/repo")
+	assert.NoError(t, err)
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/repo/main.go", "Liam White"},
+		{"/repo/vendor/lib/lib.go", "Acme, Inc."},
+		{"/repo/docs/index.md", "Docs Team"},
+		{"/repo/pkg/docs/index.md", "Liam White"},
+		{"/elsewhere/main.go", ""},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.want, rules.Owner(tc.path))
+		})
+	}
+}
+
+func TestParseOwnerRules_MissingOwner(t *testing.T) {
+	_, err := ParseOwnerRules(strings.NewReader("*.go\n"), "/repo")
+	assert.EqualError(t, err, `line 1: expected a pattern followed by an owner, got "*.go"`)
+}
+
+func TestReadAuthors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "licenser")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "AUTHORS")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("# Authors of this project\n\nAcme, Inc. <legal@acme.example>\nLiam White\n"), 0644))
+
+	got, err := ReadAuthors(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme, Inc.", got)
+}
+
+func TestFirstOwner(t *testing.T) {
+	none := func(string) string { return "" }
+	assert.Equal(t, "Acme", FirstOwner(nil, none, FixedOwner("Acme"), FixedOwner("Liam White"))("main.go"))
+	assert.Equal(t, "", FirstOwner(none)("main.go"))
+}
+
+func TestMutator_Apply_OwnerStrategy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "licenser")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ours, theirs := filepath.Join(dir, "main.go"), filepath.Join(dir, "acme.go")
+	for _, path := range []string{ours, theirs} {
+		assert.NoError(t, ioutil.WriteFile(path, []byte("package main\n"), 0644))
+	}
+	owners := func(path string) string {
+		if path == theirs {
+			return "Acme, Inc."
+		}
+		return ""
+	}
+
+	m := New(license.NewApache20(2019, ""), WithOwnerStrategy(FirstOwner(owners, FixedOwner("Liam White"))))
+	for _, path := range []string{ours, theirs} {
+		assert.True(t, m.Apply(path, false))
+	}
+	written, _ := ioutil.ReadFile(ours)
+	assert.True(t, strings.HasPrefix(string(written), "// Copyright 2019 Liam White\n"))
+	written, _ = ioutil.ReadFile(theirs)
+	assert.True(t, strings.HasPrefix(string(written), "// Copyright 2019 Acme, Inc.\n"))
+
+	m = New(license.NewApache20(2019, ""), WithOwnerStrategy(owners))
+	assert.False(t, m.Apply(ours, false))
+}
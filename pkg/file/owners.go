@@ -0,0 +1,51 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// Copyrights returns the copyright notices in the header comments of the file at path.
+// It returns false if the file's language can't be identified or it can't be read.
+func Copyrights(path string, placement Placement) ([]license.Copyright, bool) {
+	style := identifyLanguageStyle(path)
+	if style == nil {
+		return nil, false
+	}
+	contents := getFileContents(path)
+	if contents == nil {
+		return nil, false
+	}
+	notices := []license.Copyright{}
+	for _, c := range headerCopyrights(splitLines(contents), style, placement) {
+		notices = append(notices, c.notice)
+	}
+	return notices, true
+}
+
+// headerCopyrights returns the copyright notices in comments starting within the header's allowed lines,
+// whatever license they're under
+func headerCopyrights(lines [][]byte, style *languageStyle, placement Placement) []copyrightLine {
+	l := layout{style: style, format: DefaultFormat(), placement: placement, gaps: detectGaps}
+	found := []copyrightLine{}
+	for _, block := range commentBlocks(lines, l, 0) {
+		if block.start >= placement.MaxLine {
+			break
+		}
+		found = append(found, findCopyrights(lines, block, style)...)
+	}
+	return found
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/denormal/go-gitignore"
+)
+
+// pathPattern is a gitignore style pattern relative to a base directory. Patterns matching a directory
+// also match everything beneath it.
+type pathPattern struct {
+	text    string
+	pattern gitignore.GitIgnore
+}
+
+func newPathPattern(text, base string) pathPattern {
+	return pathPattern{text: text, pattern: gitignore.New(strings.NewReader(text), base, nil)}
+}
+
+// matches returns true if the pattern matches rel, a path relative to the base directory, or one of its parents
+func (p pathPattern) matches(rel string) bool {
+	if p.pattern.Relative(rel, false) != nil {
+		return true
+	}
+	for dir := filepath.Dir(rel); dir != "." && dir != string(filepath.Separator); dir = filepath.Dir(dir) {
+		if p.pattern.Relative(dir, true) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// specificity ranks how narrow the pattern is by the length of its literal, non wildcard, parts
+func (p pathPattern) specificity() int {
+	count := 0
+	for _, r := range strings.Trim(p.text, "/") {
+		switch r {
+		case '*', '?', '[', ']':
+		default:
+			count++
+		}
+	}
+	return count
+}
+
+// relativeTo returns path relative to the absolute directory base, or false if it's outside it
+func relativeTo(base, path string) (string, bool) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(base, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
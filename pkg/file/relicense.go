@@ -0,0 +1,129 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// Relicense replaces the from license header in the file passed with the mutator's license,
+// keeping the existing copyright notices. Duplicate copies of the from header are removed.
+// If dryRun the change is printed as a diff instead.
+func (m *Mutator) Relicense(from license.Handler, path string, dryRun bool) bool {
+	// If we can't detect language skip (return true)
+	style := identifyLanguageStyle(path)
+	if style == nil {
+		return true
+	}
+	contents := getFileContents(path)
+	if contents == nil {
+		return false
+	}
+	l := m.layout(style)
+	in := inspect(contents, from, l)
+	if len(in.blocks) == 0 {
+		// Nothing to replace, either there's no header or it's a different license
+		return true
+	}
+	block := in.blocks[0]
+
+	rendered, err := license.Render(m.handler(path, style))
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error rendering license for %v: %v\n", path, err)
+		return false
+	}
+	text := keepCopyrights(string(rendered), string(uncomment(in.lines[block.start:block.end], style)))
+	header := splitLines(l.format.render(strings.NewReader(text), style))
+
+	// Later copies come after the first, so removing them leaves its lines where they were
+	lines := removeHeaders(inspection{lines: in.lines, blocks: in.blocks[1:]})
+	for _, duplicate := range in.blocks[1:] {
+		_, _ = fmt.Fprintf(os.Stderr, "removing duplicate license header from %v on line %d\n", path, duplicate.start+1)
+	}
+	newLines := append(append(append([][]byte{}, lines[:block.start]...), header...), lines[block.end:]...)
+
+	if dryRun {
+		printDiff(os.Stdout, path, in.lines, newLines, 0)
+		return true
+	}
+	if err := os.WriteFile(path, joinLines(newLines), 0644); err != nil { // nolint: gosec
+		_, _ = fmt.Fprintf(os.Stderr, "error writing license to %v:%v", path, err)
+		return false
+	}
+	return true
+}
+
+// keepCopyrights replaces the copyright notices in the new license text with those from the old one,
+// written with the new license's prefix (e.g. "Copyright (c)").
+func keepCopyrights(newText, oldText string) string {
+	old := license.FindCopyrights(oldText)
+	if len(old) == 0 {
+		return newText
+	}
+	result := []string{}
+	replaced := false
+	for _, line := range strings.Split(strings.TrimSuffix(newText, "\n"), "\n") {
+		c, ok := license.ParseCopyright(line)
+		if !ok {
+			result = append(result, line)
+			continue
+		}
+		if replaced {
+			continue
+		}
+		for _, o := range old {
+			o.Prefix = c.Prefix
+			result = append(result, o.String())
+		}
+		replaced = true
+	}
+	if !replaced {
+		// The new license has nowhere to put them, keep them above it
+		notices := []string{}
+		for _, o := range old {
+			notices = append(notices, o.String())
+		}
+		result = append(append(notices, ""), result...)
+	}
+	return strings.Join(result, "\n") + "\n"
+}
+
+// printDiff writes a unified diff of the old lines, starting at the zero based line start, being replaced with the new.
+// Lines that are the same at the beginning and end of both are left out.
+func printDiff(w io.Writer, path string, old, new [][]byte, start int) {
+	for len(old) > 0 && len(new) > 0 && bytes.Equal(old[0], new[0]) {
+		old, new = old[1:], new[1:]
+		start++
+	}
+	for len(old) > 0 && len(new) > 0 && bytes.Equal(old[len(old)-1], new[len(new)-1]) {
+		old, new = old[:len(old)-1], new[:len(new)-1]
+	}
+	buf := bytes.NewBuffer([]byte{})
+	_, _ = fmt.Fprintf(buf, "--- a/%s\n+++ b/%s\n", path, path)
+	_, _ = fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", start+1, len(old), start+1, len(new))
+	for _, line := range old {
+		_, _ = fmt.Fprintf(buf, "-%s\n", line)
+	}
+	for _, line := range new {
+		_, _ = fmt.Fprintf(buf, "+%s\n", line)
+	}
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+func Test_keepCopyrights(t *testing.T) {
+	tests := []struct {
+		name    string
+		newText string
+		oldText string
+		want    string
+	}{
+		{
+			name:    "replaced with new prefix",
+			newText: "Copyright (c) 2026 New\n\nPermission is hereby granted\n",
+			oldText: "Copyright 2019-2021 Old\n\nLicensed under the Apache License\n",
+			want:    "Copyright (c) 2019-2021 Old\n\nPermission is hereby granted\n",
+		},
+		{
+			name:    "several notices",
+			newText: "Copyright 2026 New\nLicense text\n",
+			oldText: "Copyright 2019 First\nCopyright 2020 Second\nOld text\n",
+			want:    "Copyright 2019 First\nCopyright 2020 Second\nLicense text\n",
+		},
+		{
+			name:    "no old notices",
+			newText: "Copyright 2026 New\nLicense text\n",
+			oldText: "Old text\n",
+			want:    "Copyright 2026 New\nLicense text\n",
+		},
+		{
+			name:    "nowhere to put them",
+			newText: "This is free and unencumbered software\n",
+			oldText: "Copyright 2019 Old\nOld text\n",
+			want:    "Copyright 2019 Old\n\nThis is free and unencumbered software\n",
+		},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, keepCopyrights(tc.newText, tc.oldText))
+		})
+	}
+}
+
+func TestMutator_Relicense_Duplicates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "licenser")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.sh")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(testHeader+"\n"+testHeader+"\necho hi\n"), 0644))
+
+	mit, _ := license.LookupBuiltin("MIT")
+	m := New(mit.Handler(2026, "Test"))
+	assert.True(t, m.Relicense(license.NewApache20(0, ""), path, false))
+	written, _ := ioutil.ReadFile(path)
+	assert.Equal(t, 1, strings.Count(string(written), "# Copyright (c) 2019 Test\n"))
+	assert.NotContains(t, string(written), "Apache")
+	assert.True(t, strings.HasSuffix(string(written), "SOFTWARE.\n\necho hi\n"), string(written))
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// OwnerRewrite is a copyright holder renamed on a line of a file
+type OwnerRewrite struct {
+	// Line is the line the notice is on, counting from one
+	Line int
+
+	From string
+	To   string
+}
+
+// RewriteOwners renames the holders of the copyright notices in the header comments of the file at path,
+// keeping their years, and returns each rename made. If dryRun the change is printed as a diff instead.
+// It returns false if the file can't be read or written.
+func RewriteOwners(path string, renames license.OwnerRenames, placement Placement, dryRun bool) ([]OwnerRewrite, bool) {
+	// If we can't detect language skip (return true)
+	style := identifyLanguageStyle(path)
+	if style == nil {
+		return nil, true
+	}
+	contents := getFileContents(path)
+	if contents == nil {
+		return nil, false
+	}
+	lines := splitLines(contents)
+	rewritten := append([][]byte{}, lines...)
+	rewrites := []OwnerRewrite{}
+	for _, c := range headerCopyrights(lines, style, placement) {
+		to, ok := renames.Rename(c.notice.Holder)
+		if !ok || to == c.notice.Holder {
+			continue
+		}
+		line := string(lines[c.line])
+		idx := strings.LastIndex(line, c.notice.Holder)
+		rewritten[c.line] = []byte(line[:idx] + to + line[idx+len(c.notice.Holder):])
+		rewrites = append(rewrites, OwnerRewrite{Line: c.line + 1, From: c.notice.Holder, To: to})
+	}
+	if len(rewrites) == 0 {
+		return rewrites, true
+	}
+
+	if dryRun {
+		printDiff(os.Stdout, path, lines, rewritten, 0)
+		return rewrites, true
+	}
+	if err := os.WriteFile(path, joinLines(rewritten), 0644); err != nil { // nolint: gosec
+		_, _ = fmt.Fprintf(os.Stderr, "error rewriting copyright owner in %v:%v", path, err)
+		return rewrites, false
+	}
+	return rewrites, true
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+func TestRewriteOwners(t *testing.T) {
+	dir, err := ioutil.TempDir("", "licenser")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "test.sh")
+	contents := "#!/bin/bash\n\n# Copyright 2019-2021 OldCo Inc. All rights reserved.\n# Copyright 2022 Someone Else\n\n# OldCo Inc. wrote this\necho hi\n"
+	assert.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+
+	renames := license.OwnerRenames{license.ExactRename("OldCo Inc.", "NewCo LLC")}
+	got, ok := RewriteOwners(path, renames, DefaultPlacement(), false)
+	assert.True(t, ok)
+	assert.Equal(t, []OwnerRewrite{{Line: 3, From: "OldCo Inc", To: "NewCo LLC"}}, got)
+
+	want := "#!/bin/bash\n\n# Copyright 2019-2021 NewCo LLC. All rights reserved.\n# Copyright 2022 Someone Else\n\n# OldCo Inc. wrote this\necho hi\n"
+	written, _ := ioutil.ReadFile(path)
+	assert.Equal(t, want, string(written))
+}
+
+func Test_headerCopyrights(t *testing.T) {
+	contents := "#!/bin/bash\n\n# Copyright 2019 Liam White\n#\n# Copyright (c) 2020 Someone Else\n\necho hi\n# Copyright 2021 Too Late\n"
+	placement := DefaultPlacement()
+	placement.MaxLine = 7
+	got := headerCopyrights(splitLines([]byte(contents)), commentStyles["shell"], placement)
+	holders := []string{}
+	for _, c := range got {
+		holders = append(holders, c.notice.Holder)
+	}
+	assert.Equal(t, []string{"Liam White", "Someone Else"}, holders)
+}
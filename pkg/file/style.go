@@ -14,8 +14,13 @@
 
 package file
 
+import "sort"
+
 type languageStyle struct {
 
+	// The name of the language, used to look up per language configuration
+	name string
+
 	// Will this language use block comments for the license?
 	// If false, this will use single line comment style
 	// WARNING: NOT YET IMPLEMENTED
@@ -28,20 +33,36 @@ type languageStyle struct {
 }
 
 var commentStyles = map[string]*languageStyle{
-	"bazel":      {isBlock: false, comment: "#"},
-	"c":          {isBlock: false, comment: "//"},
-	"docker":     {isBlock: false, comment: "#"},
-	"golang":     {isBlock: false, comment: "//"},
-	"javascript": {isBlock: false, comment: "//"},
-	"lua":        {isBlock: false, comment: "--"},
-	"make":       {isBlock: false, comment: "#"},
-	"protobuf":   {isBlock: false, comment: "//"},
-	"python":     {isBlock: false, comment: "#"},
-	"rust":       {isBlock: false, comment: "//"},
-	"shell":      {isBlock: false, comment: "#"},
-	"sql":        {isBlock: false, comment: "--"},
-	"terraform":  {isBlock: false, comment: "#"},
-	"yaml":       {isBlock: false, comment: "#"},
+	"bazel":      {name: "bazel", isBlock: false, comment: "#"},
+	"c":          {name: "c", isBlock: false, comment: "//"},
+	"docker":     {name: "docker", isBlock: false, comment: "#"},
+	"golang":     {name: "golang", isBlock: false, comment: "//"},
+	"javascript": {name: "javascript", isBlock: false, comment: "//"},
+	"lua":        {name: "lua", isBlock: false, comment: "--"},
+	"make":       {name: "make", isBlock: false, comment: "#"},
+	"protobuf":   {name: "protobuf", isBlock: false, comment: "//"},
+	"python":     {name: "python", isBlock: false, comment: "#"},
+	"rust":       {name: "rust", isBlock: false, comment: "//"},
+	"shell":      {name: "shell", isBlock: false, comment: "#"},
+	"sql":        {name: "sql", isBlock: false, comment: "--"},
+	"terraform":  {name: "terraform", isBlock: false, comment: "#"},
+	"yaml":       {name: "yaml", isBlock: false, comment: "#"},
+}
+
+// Languages returns the names of the languages licenser comments headers for, e.g. "golang", sorted
+func Languages() []string {
+	names := make([]string, 0, len(commentStyles))
+	for name := range commentStyles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsLanguage returns true if name is one of the Languages
+func IsLanguage(name string) bool {
+	_, ok := commentStyles[name]
+	return ok
 }
 
 var commonExtensions = map[string]string{
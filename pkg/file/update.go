@@ -0,0 +1,81 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// UpdateYear extends the copyright years in the file's license header to the year chosen by the
+// year strategy, or replaces them if replace. If dryRun the change is printed as a diff instead.
+func (m *Mutator) UpdateYear(path string, replace, dryRun bool) bool {
+	// If we can't detect language skip (return true)
+	style := identifyLanguageStyle(path)
+	if style == nil {
+		return true
+	}
+	contents := getFileContents(path)
+	if contents == nil {
+		return false
+	}
+	in := inspect(contents, m.license, m.layout(style))
+	if len(in.blocks) == 0 || m.years == nil {
+		return true
+	}
+	block := in.blocks[0]
+	year := m.years(path).Last
+
+	header := [][]byte{}
+	changed := false
+	for _, line := range in.lines[block.start:block.end] {
+		if updated, ok := extendLine(string(line), style, year, replace); ok {
+			line = []byte(updated)
+			changed = true
+		}
+		header = append(header, line)
+	}
+	if !changed {
+		return true
+	}
+
+	if dryRun {
+		printDiff(os.Stdout, path, in.lines[block.start:block.end], header, block.start)
+		return true
+	}
+	newContents := joinLines(append(append(append([][]byte{}, in.lines[:block.start]...), header...), in.lines[block.end:]...))
+	if err := os.WriteFile(path, newContents, 0644); err != nil { // nolint: gosec
+		_, _ = fmt.Fprintf(os.Stderr, "error updating license year in %v:%v", path, err)
+		return false
+	}
+	return true
+}
+
+// extendLine rewrites the years of the copyright notice on the commented line, leaving the rest of the line as it was
+func extendLine(line string, style *languageStyle, year int, replace bool) (string, bool) {
+	c, ok := license.ParseCopyright(string(uncomment([][]byte{[]byte(line)}, style)))
+	if !ok {
+		return line, false
+	}
+	extended := c.Extend(year, replace)
+	if extended.Years == c.Years {
+		return line, false
+	}
+	idx := strings.Index(line, c.Years)
+	return line[:idx] + extended.Years + line[idx+len(c.Years):], true
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_extendLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		want   string
+		wantOK bool
+	}{
+		{"extended", "# Copyright 2019 Liam White", "# Copyright 2019-2026 Liam White", true},
+		{"suffix kept", "  # Copyright (c) 2019 Acme. All rights reserved.", "  # Copyright (c) 2019-2026 Acme. All rights reserved.", true},
+		{"spdx", "# SPDX-FileCopyrightText: 2019-2021 Acme", "# SPDX-FileCopyrightText: 2019-2026 Acme", true},
+		{"up to date", "# Copyright 2026 Liam White", "# Copyright 2026 Liam White", false},
+		{"not a notice", "# Licensed under the Apache License", "# Licensed under the Apache License", false},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := extendLine(tc.line, commentStyles["shell"], 2026, false)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// YearStrategy returns the copyright year(s) to write in the header of the file at path
+type YearStrategy func(path string) license.YearRange
+
+// FixedYear uses the same year(s) for every file
+func FixedYear(year license.YearRange) YearStrategy {
+	return func(string) license.YearRange {
+		return year
+	}
+}
+
+// CurrentYear is the year of SOURCE_DATE_EPOCH if set, so builds are reproducible, otherwise the year now
+func CurrentYear() (int, error) {
+	epoch, ok := os.LookupEnv("SOURCE_DATE_EPOCH")
+	if !ok || epoch == "" {
+		return time.Now().Year(), nil
+	}
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q, must be a number of seconds since the Unix epoch", epoch)
+	}
+	return time.Unix(seconds, 0).UTC().Year(), nil
+}
+
+// GitYear uses the year the file was first committed, or if asRange the years of its first and last commits.
+// Files git doesn't know about get the current year. The history is looked up in, and cached by, history.
+func GitYear(history *GitHistory, current int, asRange bool) YearStrategy {
+	return func(path string) license.YearRange {
+		history := history.commits(path)
+		if len(history) == 0 {
+			return license.Year(current)
+		}
+		result := license.Year(history[len(history)-1].year)
+		if asRange {
+			result.Last = history[0].year
+		}
+		return result
+	}
+}
+
+// ModifiedYear uses the year the file was last modified, clamped to the current year so that
+// SOURCE_DATE_EPOCH keeps the output reproducible.
+func ModifiedYear(current int) YearStrategy {
+	return func(path string) license.YearRange {
+		info, err := os.Stat(path)
+		if err != nil || info.ModTime().Year() > current {
+			return license.Year(current)
+		}
+		return license.Year(info.ModTime().Year())
+	}
+}
+
+// commit is one of the commits touching a file
+type commit struct {
+	year   int
+	author string
+}
+
+// GitHistory looks up the commits touching files with git. Each file is only looked up once, however many
+// year strategies and checks need its history.
+type GitHistory struct {
+	mu    sync.Mutex
+	files map[string]*fileHistory
+}
+
+type fileHistory struct {
+	once    sync.Once
+	commits []commit
+}
+
+// NewGitHistory creates an empty history
+func NewGitHistory() *GitHistory {
+	return &GitHistory{files: map[string]*fileHistory{}}
+}
+
+// commits returns the commits touching the file, newest first, or nil if git doesn't know it
+func (h *GitHistory) commits(path string) []commit {
+	h.mu.Lock()
+	f, ok := h.files[path]
+	if !ok {
+		f = &fileHistory{}
+		h.files[path] = f
+	}
+	h.mu.Unlock()
+	f.once.Do(func() { f.commits = gitHistory(path) })
+	return f.commits
+}
+
+// gitProcesses limits how many git processes run at once
+var gitProcesses = make(chan struct{}, runtime.NumCPU())
+
+// gitHistory returns the commits touching the file, newest first, or nil if git doesn't know it
+func gitHistory(path string) []commit {
+	gitProcesses <- struct{}{}
+	defer func() { <-gitProcesses }()
+	cmd := exec.Command("git", "log", "--follow", "--format=%ad\t%an", "--date=format:%Y", "--", filepath.Base(path))
+	cmd.Dir = filepath.Dir(path)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	commits := []commit{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.SplitN(line, "\t", 2)
+		year, err := strconv.Atoi(fields[0])
+		if err != nil || len(fields) < 2 {
+			continue
+		}
+		commits = append(commits, commit{year: year, author: fields[1]})
+	}
+	return commits
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liamawhite/licenser/pkg/license"
+)
+
+// gitRepo creates a git repository in a temp dir, returning it and a function committing the contents
+// to the file as the author in the year
+func gitRepo(t *testing.T) (string, func(name, contents, author string, year int)) {
+	dir, err := ioutil.TempDir("", "licenser")
+	assert.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	git := func(env []string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir, cmd.Env = dir, append(os.Environ(), env...)
+		out, err := cmd.CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+	git(nil, "init", "-q")
+	return dir, func(name, contents, author string, year int) {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644))
+		date := time.Date(year, 6, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+		git(nil, "add", name)
+		git([]string{"GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date},
+			"-c", "user.name="+author, "-c", "user.email=test@example.com", "commit", "-q", "-m", name)
+	}
+}
+
+func TestGitYear(t *testing.T) {
+	dir, commitFile := gitRepo(t)
+	commitFile("main.go", "package main\n", "Ada", 2019)
+	commitFile("main.go", "package main\n\nfunc main() {}\n", "Grace", 2021)
+	path, untracked := filepath.Join(dir, "main.go"), filepath.Join(dir, "new.go")
+	assert.NoError(t, ioutil.WriteFile(untracked, []byte("package main\n"), 0644))
+
+	tests := []struct {
+		name    string
+		path    string
+		asRange bool
+		want    license.YearRange
+	}{
+		{"first commit", path, false, license.Year(2019)},
+		{"first and last commits", path, true, license.YearRange{First: 2019, Last: 2021}},
+		{"untracked", untracked, false, license.Year(2026)},
+		{"untracked range", untracked, true, license.Year(2026)},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, GitYear(NewGitHistory(), 2026, tc.asRange)(tc.path))
+		})
+	}
+}
+
+func TestGitHistory_Cached(t *testing.T) {
+	dir, commitFile := gitRepo(t)
+	commitFile("main.go", "package main\n", "Ada", 2019)
+	path := filepath.Join(dir, "main.go")
+
+	history := NewGitHistory()
+	assert.Equal(t, []commit{{year: 2019, author: "Ada"}}, history.commits(path))
+
+	// Later lookups don't ask git again
+	assert.NoError(t, os.RemoveAll(filepath.Join(dir, ".git")))
+	assert.Equal(t, []commit{{year: 2019, author: "Ada"}}, history.commits(path))
+	assert.Nil(t, NewGitHistory().commits(path))
+}
+
+func TestModifiedYear(t *testing.T) {
+	dir, err := ioutil.TempDir("", "licenser")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "main.go")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("package main\n"), 0644))
+
+	tests := []struct {
+		name     string
+		modified time.Time
+		want     license.YearRange
+	}{
+		{"past", time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), license.Year(2020)},
+		{"after the current year", time.Date(2030, 3, 1, 0, 0, 0, 0, time.UTC), license.Year(2026)},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NoError(t, os.Chtimes(path, tc.modified, tc.modified))
+			assert.Equal(t, tc.want, ModifiedYear(2026)(path))
+		})
+	}
+	assert.Equal(t, license.Year(2026), ModifiedYear(2026)(filepath.Join(dir, "missing.go")))
+}
+
+func TestCurrentYear(t *testing.T) {
+	tests := []struct {
+		name    string
+		epoch   string
+		want    int
+		wantErr bool
+	}{
+		{"unset", "", time.Now().Year(), false},
+		{"SOURCE_DATE_EPOCH", "1577836800", 2020, false},
+		{"invalid SOURCE_DATE_EPOCH", "yesterday", 0, true},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("SOURCE_DATE_EPOCH", tc.epoch)
+			got, err := CurrentYear()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
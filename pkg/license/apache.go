@@ -35,5 +35,5 @@ limitations under the License.
 
 // NewApache20 creates a new Apache 2.0 license handler
 func NewApache20(year int, owner string) *Generic {
-	return FromTemplateString(license, mark, year, owner)
+	return builtinHandler(license, mark, year, owner)
 }
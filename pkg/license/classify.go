@@ -0,0 +1,97 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// Unknown is the classification of a header that mentions a license or copyright but isn't recognised
+	Unknown = "unknown"
+
+	// None is the classification of text with no license header
+	None = "none"
+)
+
+// ClassifyThreshold is the lowest similarity at which a header without the license's marker is still
+// classified as that license
+const ClassifyThreshold = 0.75
+
+// looksLikeHeader matches text that is probably a license or copyright notice
+var looksLikeHeader = regexp.MustCompile(`(?i)copyright|licen[cs]e|\(c\)|©`)
+
+// Classify identifies the license in the passed (uncommented) header text.
+// An SPDX-License-Identifier takes precedence, otherwise the builtin license most similar to the text is
+// returned, preferring those whose marker is present. Unknown or None is returned if nothing matches.
+func Classify(text string) string {
+	if expression, ok := FindIdentifier(strings.NewReader(text)); ok {
+		if parsed, err := ParseExpression(expression); err == nil {
+			return parsed.String()
+		}
+		return expression
+	}
+	if !looksLikeHeader.MatchString(text) {
+		return None
+	}
+
+	best, bestScore, bestMarked := "", 0.0, false
+	for _, b := range Builtins() {
+		h := b.Handler(0, "")
+		marked := h.IsPresent(strings.NewReader(text))
+		if bestMarked && !marked {
+			continue
+		}
+		score := h.Compare(text).Score
+		if (marked && !bestMarked) || score > bestScore {
+			best, bestScore, bestMarked = b.ID, score, marked
+		}
+	}
+	if bestMarked || bestScore >= ClassifyThreshold {
+		return best
+	}
+	return Unknown
+}
+
+// licenseFileTitles identify full license texts, as found in LICENSE files, by their title
+var licenseFileTitles = []struct {
+	id    string
+	title *regexp.Regexp
+}{
+	{"Apache-2.0", regexp.MustCompile(`(?i)Apache License\s+Version 2\.0`)},
+	{"MPL-2.0", regexp.MustCompile(`(?i)Mozilla Public License,?\s+Version 2\.0`)},
+	{"EPL-2.0", regexp.MustCompile(`(?i)Eclipse Public License\s+-\s+v\s+2\.0`)},
+	{"CC-BY-4.0", regexp.MustCompile(`(?i)Attribution 4\.0 International`)},
+	{"AGPL-3.0-only", regexp.MustCompile(`(?i)GNU Affero General Public License\s+Version 3`)},
+	{"LGPL-3.0-only", regexp.MustCompile(`(?i)GNU Lesser General Public License\s+Version 3`)},
+	{"LGPL-2.1-only", regexp.MustCompile(`(?i)GNU Lesser General Public License\s+Version 2\.1`)},
+	{"GPL-3.0-only", regexp.MustCompile(`(?i)GNU General Public License\s+Version 3`)},
+	{"GPL-2.0-only", regexp.MustCompile(`(?i)GNU General Public License\s+Version 2`)},
+}
+
+// ClassifyLicenseFile identifies the license in the text of a LICENSE or COPYING file. Full license texts
+// are recognised by their title, GNU licenses as the "only" variant as the text alone doesn't say whether
+// later versions may be used. Other files are classified like headers.
+func ClassifyLicenseFile(text string) string {
+	lines := strings.SplitN(text, "\n", 21)
+	head := strings.Join(lines[:min(len(lines), 20)], "\n")
+	for _, t := range licenseFileTitles {
+		if t.title.MatchString(head) {
+			return t.id
+		}
+	}
+	return Classify(text)
+}
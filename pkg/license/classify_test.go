@@ -0,0 +1,67 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	golden, _ := ioutil.ReadFile("testdata/apache.golden")
+	bsd3, _ := ioutil.ReadAll(builtins["BSD-3-Clause"].Handler(2019, "Test").Reader())
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"Apache", string(golden), "Apache-2.0"},
+		{"Apache with a typo", strings.Replace(string(golden), "Apache License", "Apache Licence", 1), "Apache-2.0"},
+		{"BSD-3-Clause is not BSD-2-Clause", string(bsd3), "BSD-3-Clause"},
+		{"SPDX identifier", "SPDX-License-Identifier: mit OR apache-2.0\n", "MIT OR Apache-2.0"},
+		{"Unknown", "Copyright 2019 Test\nAll rights reserved.\n", Unknown},
+		{"None", "Package file does things\n", None},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Classify(tc.text))
+		})
+	}
+}
+
+func TestClassifyLicenseFile(t *testing.T) {
+	mit, _ := ioutil.ReadAll(builtins["MIT"].Handler(2019, "Test").Reader())
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"Apache", "\n                                 Apache License\n                           Version 2.0, January 2004\n", "Apache-2.0"},
+		{"GPL", "                    GNU GENERAL PUBLIC LICENSE\n                       Version 3, 29 June 2007\n", "GPL-3.0-only"},
+		{"LGPL is not GPL", "                   GNU LESSER GENERAL PUBLIC LICENSE\n                       Version 2.1, February 1999\n", "LGPL-2.1-only"},
+		{"MIT", "MIT License\n\n" + string(mit), "MIT"},
+		{"Unknown", "Copyright 2019 Test\nAll rights reserved.\n", Unknown},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ClassifyLicenseFile(tc.text))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+// FileContext describes the file a header is rendered for, so templates can vary from file to file,
+// e.g. "This file is part of {{.ProjectName}}" or {{if eq .Language "golang"}}...{{end}}
+type FileContext struct {
+	// FileName is the file's base name, e.g. "main.go"
+	FileName string
+
+	// RelPath is the file's path relative to the project root, with forward slashes
+	RelPath string
+
+	// Language is the name of the file's language, e.g. "golang"
+	Language string
+
+	// ProjectName is the name of the project
+	ProjectName string
+
+	// Vars are user defined values, used as {{.Vars.key}}
+	Vars map[string]string
+
+	// History returns the year the file was first committed and the author of that commit.
+	// It's only called if the template uses FirstCommitYear or Author.
+	History func() (year int, author string)
+}
+
+// Contextual is a Handler that can render its header for a particular file
+type Contextual interface {
+	Handler
+
+	// WithContext returns a copy of the handler rendering the header for the described file
+	WithContext(ctx FileContext) Handler
+}
+
+// FirstCommitYear returns the year the file was first committed, or 0 if it's unknown
+func (c FileContext) FirstCommitYear() int {
+	if c.History == nil {
+		return 0
+	}
+	year, _ := c.History()
+	return year
+}
+
+// Author returns the author of the file's first commit, or "" if it's unknown
+func (c FileContext) Author() string {
+	if c.History == nil {
+		return ""
+	}
+	_, author := c.History()
+	return author
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Copyright is a parsed copyright notice, e.g. "Copyright (c) 2019-2021 Liam White. All rights reserved."
+type Copyright struct {
+	// Prefix is everything before the years, e.g. "Copyright (c)" or "SPDX-FileCopyrightText:"
+	Prefix string
+
+	// Years are the years as written, e.g. "2019", "2019-2021" or "2019, 2021". May be empty.
+	Years string
+
+	// Holder is the copyright holder
+	Holder string
+
+	// Suffix is an "All rights reserved." statement following the holder, if any
+	Suffix string
+}
+
+var (
+	copyrightPattern = regexp.MustCompile(`^(?i)((?:SPDX-FileCopyrightText:|Copyright|\(c\)|©)(?:\s*(?:\(c\)|©))?)\s*((?:\d{4})(?:\s*(?:-|–|,)\s*\d{4})*)?,?\s*(.*?)\s*$`)
+	suffixPattern    = regexp.MustCompile(`(?i)[.,]?\s*(all rights reserved\.?)$`)
+	yearPattern      = regexp.MustCompile(`\d{4}`)
+	lastRangePattern = regexp.MustCompile(`(\d{4})(\s*(?:-|–)\s*)\d{4}$`)
+)
+
+// ParseCopyright parses a single line of (uncommented) text as a copyright notice
+func ParseCopyright(line string) (Copyright, bool) {
+	match := copyrightPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return Copyright{}, false
+	}
+	c := Copyright{Prefix: match[1], Years: match[2], Holder: match[3]}
+	if suffix := suffixPattern.FindStringSubmatchIndex(c.Holder); suffix != nil {
+		c.Suffix = c.Holder[suffix[2]:suffix[3]]
+		c.Holder = strings.TrimSpace(c.Holder[:suffix[0]])
+	}
+	// Prose that happens to start with "copyright" (e.g. "copyright notice and this permission notice")
+	// isn't a notice, without years we need a (c), © or SPDX tag to be sure
+	if c.Years == "" && (c.Holder == "" || strings.EqualFold(c.Prefix, "copyright")) {
+		return Copyright{}, false
+	}
+	return c, true
+}
+
+// FindCopyrights returns every copyright notice in the passed (uncommented) text
+func FindCopyrights(text string) []Copyright {
+	result := []Copyright{}
+	for _, line := range strings.Split(text, "\n") {
+		if c, ok := ParseCopyright(line); ok {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// String formats the notice, e.g. "Copyright 2019 Liam White"
+func (c Copyright) String() string {
+	parts := []string{c.Prefix}
+	for _, part := range []string{c.Years, c.Holder} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	result := strings.Join(parts, " ")
+	if c.Suffix != "" {
+		if !strings.HasSuffix(result, ".") {
+			result += "."
+		}
+		result += " " + c.Suffix
+	}
+	return result
+}
+
+// Span returns the earliest and latest years the notice covers, or a zero range if it has no years
+func (c Copyright) Span() YearRange {
+	span := YearRange{}
+	for _, y := range yearPattern.FindAllString(c.Years, -1) {
+		year, _ := strconv.Atoi(y)
+		if span.First == 0 || year < span.First {
+			span.First = year
+		}
+		if year > span.Last {
+			span.Last = year
+		}
+	}
+	return span
+}
+
+// Extend returns the notice with its years extended to cover year, e.g. "2019" becomes "2019-2026",
+// or if replace with its years replaced by year. Notices without years or already covering year are unchanged.
+func (c Copyright) Extend(year int, replace bool) Copyright {
+	last := c.Span().Last
+	if last == 0 || last >= year {
+		return c
+	}
+	switch {
+	case replace:
+		c.Years = strconv.Itoa(year)
+	case lastRangePattern.MatchString(c.Years):
+		c.Years = lastRangePattern.ReplaceAllString(c.Years, "${1}${2}"+strconv.Itoa(year))
+	default:
+		c.Years += "-" + strconv.Itoa(year)
+	}
+	return c
+}
+
+// addHolders adds a copy of the copyright line naming the first holder for each of the others,
+// unless the text already has a copyright line naming them
+func addHolders(text string, holders []string) string {
+	if len(holders) < 2 {
+		return text
+	}
+	named := map[string]bool{}
+	for _, c := range FindCopyrights(text) {
+		named[strings.ToLower(c.Holder)] = true
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		c, ok := ParseCopyright(line)
+		if !ok || !strings.EqualFold(c.Holder, holders[0]) {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		extra := []string{}
+		for _, holder := range holders[1:] {
+			if named[strings.ToLower(holder)] {
+				continue
+			}
+			c.Holder = holder
+			extra = append(extra, indent+c.String())
+		}
+		return strings.Join(append(append(append([]string{}, lines[:i+1]...), extra...), lines[i+1:]...), "\n")
+	}
+	return text
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCopyright(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   Copyright
+		wantOK bool
+	}{
+		{"Copyright 2019 Liam White", Copyright{Prefix: "Copyright", Years: "2019", Holder: "Liam White"}, true},
+		{"Copyright (c) 2019-2021 Acme, Inc.", Copyright{Prefix: "Copyright (c)", Years: "2019-2021", Holder: "Acme, Inc."}, true},
+		{"Copyright (C) 2019, 2021 Acme. All rights reserved.", Copyright{Prefix: "Copyright (C)", Years: "2019, 2021", Holder: "Acme", Suffix: "All rights reserved."}, true},
+		{"Copyright 2019", Copyright{Prefix: "Copyright", Years: "2019"}, true},
+		{"© Acme", Copyright{Prefix: "©", Holder: "Acme"}, true},
+		{"SPDX-FileCopyrightText: 2026 Our Corp", Copyright{Prefix: "SPDX-FileCopyrightText:", Years: "2026", Holder: "Our Corp"}, true},
+		{"copyright notice and this permission notice appear in all copies.", Copyright{}, false},
+		{"Licensed under the Apache License", Copyright{}, false},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.line, func(t *testing.T) {
+			got, ok := ParseCopyright(tc.line)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.want, got)
+			if ok {
+				assert.Equal(t, tc.line, got.String())
+			}
+		})
+	}
+}
+
+func TestCopyright_Extend(t *testing.T) {
+	tests := []struct {
+		years   string
+		replace bool
+		want    string
+	}{
+		{"2019", false, "2019-2026"},
+		{"2019-2021", false, "2019-2026"},
+		{"2019 – 2021", false, "2019 – 2026"},
+		{"2019, 2021", false, "2019, 2021-2026"},
+		{"2026", false, "2026"},
+		{"2019-2026", false, "2019-2026"},
+		{"2019-2021", true, "2026"},
+		{"", false, ""},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.years, func(t *testing.T) {
+			c := Copyright{Prefix: "Copyright", Years: tc.years, Holder: "Liam White"}
+			assert.Equal(t, tc.want, c.Extend(2026, tc.replace).Years)
+		})
+	}
+}
+
+func TestCopyright_Span(t *testing.T) {
+	assert.Equal(t, YearRange{2019, 2019}, Copyright{Years: "2019"}.Span())
+	assert.Equal(t, YearRange{2017, 2026}, Copyright{Years: "2019, 2017, 2021-2026"}.Span())
+	assert.Equal(t, YearRange{}, Copyright{}.Span())
+}
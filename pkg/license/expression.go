@@ -0,0 +1,394 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+// Expression is a parsed SPDX license expression, e.g. "MIT OR Apache-2.0"
+type Expression interface {
+	// String returns the expression in canonical form
+	String() string
+
+	// Licenses returns the license IDs the expression refers to
+	Licenses() []string
+
+	// Satisfied returns true if the expression holds when only licenses accepted by allowed may be used
+	Satisfied(allowed func(id string) bool) bool
+}
+
+// Operator joins two license expressions
+type Operator string
+
+const (
+	// And requires both licenses to be complied with
+	And Operator = "AND"
+	// Or allows either license to be chosen
+	Or Operator = "OR"
+)
+
+// SimpleExpression is a single license, optionally with an exception
+type SimpleExpression struct {
+	// ID is the SPDX license ID or a LicenseRef
+	ID string
+
+	// OrLater is true if the ID was followed by "+"
+	OrLater bool
+
+	// Exception is the SPDX exception ID following WITH, if any
+	Exception string
+}
+
+// String returns the license in canonical form
+func (s *SimpleExpression) String() string {
+	result := s.ID
+	if s.OrLater {
+		result += "+"
+	}
+	if s.Exception != "" {
+		result += " WITH " + s.Exception
+	}
+	return result
+}
+
+// Licenses returns the single license ID
+func (s *SimpleExpression) Licenses() []string {
+	return []string{s.ID}
+}
+
+// Satisfied returns true if the license is allowed
+func (s *SimpleExpression) Satisfied(allowed func(id string) bool) bool {
+	return allowed(s.ID)
+}
+
+// CompoundExpression joins two expressions with AND or OR
+type CompoundExpression struct {
+	Operator    Operator
+	Left, Right Expression
+}
+
+// String returns the expression in canonical form, only adding parentheses where they're needed
+func (c *CompoundExpression) String() string {
+	return c.operand(c.Left) + " " + string(c.Operator) + " " + c.operand(c.Right)
+}
+
+func (c *CompoundExpression) operand(e Expression) string {
+	if child, ok := e.(*CompoundExpression); ok && child.Operator == Or && c.Operator == And {
+		return "(" + child.String() + ")"
+	}
+	return e.String()
+}
+
+// Licenses returns the license IDs on both sides of the operator
+func (c *CompoundExpression) Licenses() []string {
+	return append(c.Left.Licenses(), c.Right.Licenses()...)
+}
+
+// Satisfied evaluates the expression against the allowed licenses
+func (c *CompoundExpression) Satisfied(allowed func(id string) bool) bool {
+	if c.Operator == And {
+		return c.Left.Satisfied(allowed) && c.Right.Satisfied(allowed)
+	}
+	return c.Left.Satisfied(allowed) || c.Right.Satisfied(allowed)
+}
+
+// ExpressionError describes a problem with a token in a license expression
+type ExpressionError struct {
+	// Token is the offending part of the expression, empty at the end of the expression
+	Token string
+
+	// Position is the column, counting from one, the token starts at
+	Position int
+
+	// Reason describes what is wrong with the token
+	Reason string
+
+	// Hint suggests how to fix the token, if there's an obvious fix
+	Hint string
+}
+
+func (e *ExpressionError) Error() string {
+	msg := fmt.Sprintf("%s at end of expression", e.Reason)
+	if e.Token != "" {
+		msg = fmt.Sprintf("%s %q at position %d", e.Reason, e.Token, e.Position)
+	}
+	if e.Hint != "" {
+		msg += ", " + e.Hint
+	}
+	return msg
+}
+
+type token struct {
+	text     string
+	position int
+}
+
+// ParseExpression parses an SPDX license expression. License IDs are not checked against the SPDX list,
+// use ValidateExpression for that.
+func ParseExpression(expression string) (Expression, error) {
+	p := &parser{tokens: lex(expression)}
+	if len(p.tokens) == 0 {
+		return nil, &ExpressionError{Reason: "empty expression"}
+	}
+	result, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := p.peek(); ok {
+		return nil, &ExpressionError{Token: t.text, Position: t.position, Reason: "unexpected"}
+	}
+	return result, nil
+}
+
+// ValidateExpression parses the expression and checks that every license and exception ID is on the SPDX list
+// and not deprecated. One error is returned for each invalid token.
+func ValidateExpression(expression string) []*ExpressionError {
+	p := &parser{tokens: lex(expression), validate: true}
+	if len(p.tokens) == 0 {
+		return []*ExpressionError{{Reason: "empty expression"}}
+	}
+	if _, err := p.parseOr(); err != nil {
+		return append(p.problems, err.(*ExpressionError))
+	}
+	if t, ok := p.peek(); ok {
+		p.problems = append(p.problems, &ExpressionError{Token: t.text, Position: t.position, Reason: "unexpected"})
+	}
+	return p.problems
+}
+
+func lex(expression string) []token {
+	tokens := []token{}
+	start := -1
+	for i, r := range expression + " " {
+		switch {
+		case r == '(' || r == ')' || r == ' ' || r == '\t':
+			if start >= 0 {
+				tokens = append(tokens, token{text: expression[start:i], position: start + 1})
+				start = -1
+			}
+			if r == '(' || r == ')' {
+				tokens = append(tokens, token{text: string(r), position: i + 1})
+			}
+		case start < 0:
+			start = i
+		}
+	}
+	return tokens
+}
+
+// parser is a recursive descent parser for license expressions, WITH binds tighter than AND which binds tighter than OR
+type parser struct {
+	tokens   []token
+	next     int
+	validate bool
+	problems []*ExpressionError
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.next >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.next], true
+}
+
+func (p *parser) isOperator(op string) bool {
+	t, ok := p.peek()
+	// Operators must be all upper or all lower case
+	return ok && (t.text == op || t.text == strings.ToLower(op))
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	return p.parseCompound(Or, p.parseAnd)
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	return p.parseCompound(And, p.parseSimple)
+}
+
+func (p *parser) parseCompound(op Operator, operand func() (Expression, error)) (Expression, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator(string(op)) {
+		p.next++
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &CompoundExpression{Operator: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseSimple() (Expression, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, &ExpressionError{Reason: "expected a license"}
+	}
+	p.next++
+	if t.text == "(" {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing, ok := p.peek()
+		if !ok {
+			return nil, &ExpressionError{Reason: "missing closing parenthesis"}
+		}
+		if closing.text != ")" {
+			return nil, &ExpressionError{Token: closing.text, Position: closing.position, Reason: "expected closing parenthesis, got"}
+		}
+		p.next++
+		return inner, nil
+	}
+	if !isIDString(t.text) {
+		return nil, &ExpressionError{Token: t.text, Position: t.position, Reason: "expected a license, got"}
+	}
+
+	simple := p.license(t)
+	if p.isOperator("WITH") {
+		p.next++
+		exception, ok := p.peek()
+		if !ok {
+			return nil, &ExpressionError{Reason: "expected an exception after WITH"}
+		}
+		if !isIDString(exception.text) {
+			return nil, &ExpressionError{Token: exception.text, Position: exception.position, Reason: "expected an exception, got"}
+		}
+		p.next++
+		simple.Exception = p.exception(exception)
+	}
+	return simple, nil
+}
+
+// license resolves the token to a license, recording a problem if it isn't a current SPDX license
+func (p *parser) license(t token) *SimpleExpression {
+	id := t.text
+	if isLicenseRef(id) {
+		return &SimpleExpression{ID: id}
+	}
+	if entry, ok := lookupSPDX(spdxLicenses, id); ok {
+		p.checkDeprecated(t, entry, "license")
+		return &SimpleExpression{ID: entry.id}
+	}
+	if base := strings.TrimSuffix(id, "+"); base != id {
+		if entry, ok := lookupSPDX(spdxLicenses, base); ok {
+			p.checkDeprecated(t, entry, "license")
+			return &SimpleExpression{ID: entry.id, OrLater: true}
+		}
+	}
+	if p.validate {
+		p.problems = append(p.problems, &ExpressionError{Token: id, Position: t.position, Reason: "unknown license ID"})
+	}
+	return &SimpleExpression{ID: strings.TrimSuffix(id, "+"), OrLater: strings.HasSuffix(id, "+")}
+}
+
+// exception resolves the token to an exception, recording a problem if it isn't an SPDX exception
+func (p *parser) exception(t token) string {
+	if isLicenseRef(t.text) {
+		return t.text
+	}
+	if entry, ok := lookupSPDX(spdxExceptions, t.text); ok {
+		p.checkDeprecated(t, entry, "exception")
+		return entry.id
+	}
+	if p.validate {
+		p.problems = append(p.problems, &ExpressionError{Token: t.text, Position: t.position, Reason: "unknown exception ID"})
+	}
+	return t.text
+}
+
+func (p *parser) checkDeprecated(t token, entry spdxEntry, kind string) {
+	if p.validate && entry.deprecated {
+		problem := &ExpressionError{Token: t.text, Position: t.position, Reason: "deprecated " + kind + " ID"}
+		if entry.replacement != "" {
+			problem.Hint = "use " + entry.replacement
+		}
+		p.problems = append(p.problems, problem)
+	}
+}
+
+func isIDString(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.', r == ':':
+		case r == '+' && i == len(s)-1:
+		default:
+			return false
+		}
+	}
+	switch s {
+	case "AND", "and", "OR", "or", "WITH", "with":
+		return false
+	}
+	return true
+}
+
+func isLicenseRef(id string) bool {
+	return strings.HasPrefix(id, "LicenseRef-") || strings.HasPrefix(id, "DocumentRef-") || strings.HasPrefix(id, "AdditionRef-")
+}
+
+//go:embed spdx/licenses.txt
+var spdxLicenseList string
+
+//go:embed spdx/exceptions.txt
+var spdxExceptionList string
+
+var (
+	spdxLicenses   = parseSPDXList(spdxLicenseList)
+	spdxExceptions = parseSPDXList(spdxExceptionList)
+)
+
+type spdxEntry struct {
+	id          string
+	deprecated  bool
+	replacement string
+}
+
+// parseSPDXList reads one ID per line, optionally followed by "deprecated" and its replacements.
+// Entries are keyed by lower case ID as SPDX IDs are case insensitive.
+func parseSPDXList(list string) map[string]spdxEntry {
+	entries := map[string]spdxEntry{}
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		entry := spdxEntry{id: fields[0]}
+		if len(fields) > 1 && fields[1] == "deprecated" {
+			entry.deprecated = true
+			entry.replacement = strings.Join(fields[2:], " or ")
+			if strings.Contains(entry.replacement, "WITH") {
+				entry.replacement = strings.Join(fields[2:], " ")
+			}
+		}
+		entries[strings.ToLower(entry.id)] = entry
+	}
+	return entries
+}
+
+func lookupSPDX(list map[string]spdxEntry, id string) (spdxEntry, bool) {
+	entry, ok := list[strings.ToLower(id)]
+	return entry, ok
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+		wantErr    string
+	}{
+		{expression: "MIT", want: "MIT"},
+		{expression: "mit or apache-2.0", want: "MIT OR Apache-2.0"},
+		{expression: "GPL-2.0-only WITH Classpath-exception-2.0", want: "GPL-2.0-only WITH Classpath-exception-2.0"},
+		{expression: "(MIT OR Apache-2.0) AND BSD-3-Clause", want: "(MIT OR Apache-2.0) AND BSD-3-Clause"},
+		{expression: "MIT OR Apache-2.0 AND BSD-3-Clause", want: "MIT OR Apache-2.0 AND BSD-3-Clause"},
+		{expression: "((MIT))", want: "MIT"},
+		{expression: "Apache-2.0+", want: "Apache-2.0+"},
+		{expression: "LicenseRef-Proprietary", want: "LicenseRef-Proprietary"},
+		{expression: "", wantErr: "empty expression at end of expression"},
+		{expression: "MIT OR", wantErr: "expected a license at end of expression"},
+		{expression: "MIT Apache-2.0", wantErr: `unexpected "Apache-2.0" at position 5`},
+		{expression: "(MIT OR Apache-2.0", wantErr: "missing closing parenthesis at end of expression"},
+		{expression: "MIT WITH", wantErr: "expected an exception after WITH at end of expression"},
+		{expression: "OR MIT", wantErr: `expected a license, got "OR" at position 1`},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.expression, func(t *testing.T) {
+			got, err := ParseExpression(tc.expression)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got.String())
+		})
+	}
+}
+
+func TestValidateExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       []string
+	}{
+		{"MIT OR Apache-2.0", nil},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", nil},
+		{"LicenseRef-Proprietary", nil},
+		{"Elastic-2.0 OR ODbL-1.0", nil},
+		{"GPL-3.0-or-later WITH GPL-3.0-interface-exception", nil},
+		{"GPL-2.0-only WITH Nokia-Qt-exception-1.1", []string{`deprecated exception ID "Nokia-Qt-exception-1.1" at position 19`}},
+		{"Apach-2.0", []string{`unknown license ID "Apach-2.0" at position 1`}},
+		{"GPL-2.0", []string{`deprecated license ID "GPL-2.0" at position 1, use GPL-2.0-only or GPL-2.0-or-later`}},
+		{"Nunit", []string{`deprecated license ID "Nunit" at position 1, use zlib-acknowledgement`}},
+		{"MIT AND GPL-2.0-only WITH Made-up-exception", []string{`unknown exception ID "Made-up-exception" at position 27`}},
+		{"Nope OR Nada", []string{`unknown license ID "Nope" at position 1`, `unknown license ID "Nada" at position 9`}},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.expression, func(t *testing.T) {
+			var got []string
+			for _, problem := range ValidateExpression(tc.expression) {
+				got = append(got, problem.Error())
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestExpression_Satisfied(t *testing.T) {
+	expr, _ := ParseExpression("(MIT OR GPL-3.0-only) AND Apache-2.0")
+	allowed := func(ids ...string) func(string) bool {
+		return func(id string) bool {
+			for _, a := range ids {
+				if a == id {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	assert.True(t, expr.Satisfied(allowed("MIT", "Apache-2.0")))
+	assert.False(t, expr.Satisfied(allowed("MIT")))
+	assert.Equal(t, []string{"MIT", "GPL-3.0-only", "Apache-2.0"}, expr.Licenses())
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+)
+
+// Funcs returns the functions license templates can use:
+//
+//	upper, lower, title    change the case of a string, e.g. {{upper .Owner}}
+//	now                    the current time, or that of SOURCE_DATE_EPOCH if set, e.g. {{now.Year}}
+//	date                   formats a time with a Go layout, e.g. {{now | date "January 2006"}}
+//	env                    the value of an environment variable, e.g. {{env "TEAM"}}
+//	default                a value, or the default if it's empty, e.g. {{.Vars.team | default "core"}}
+//	yearRange              the span of two years, e.g. {{yearRange .FirstCommitYear now.Year}} is "2019-2026"
+//	join                   joins a list, e.g. {{join ", " .Owners}}
+//	indent                 indents every line of a string by a number of spaces, e.g. {{indent 4 .Vars.notice}}
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"upper":     strings.ToUpper,
+		"lower":     strings.ToLower,
+		"title":     title,
+		"now":       now,
+		"date":      date,
+		"env":       os.Getenv,
+		"default":   defaultValue,
+		"yearRange": yearRange,
+		"join":      join,
+		"indent":    indent,
+	}
+}
+
+// title upper cases the first letter of each word
+func title(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if i == 0 || unicode.IsSpace(runes[i-1]) || runes[i-1] == '-' || runes[i-1] == '(' {
+			runes[i] = unicode.ToTitle(r)
+		}
+	}
+	return string(runes)
+}
+
+// now returns the time of SOURCE_DATE_EPOCH if set, so output is reproducible, otherwise the time now
+func now() time.Time {
+	if seconds, err := strconv.ParseInt(os.Getenv("SOURCE_DATE_EPOCH"), 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC()
+	}
+	return time.Now()
+}
+
+func date(layout string, t time.Time) string {
+	return t.Format(layout)
+}
+
+// defaultValue returns value unless it's missing or its type's zero value, or an empty list or map
+func defaultValue(def, value interface{}) interface{} {
+	if value == nil {
+		return def
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		if v.Len() == 0 {
+			return def
+		}
+	default:
+		if v.IsZero() {
+			return def
+		}
+	}
+	return value
+}
+
+// yearRange formats the years as a range, or a single year if they're the same or the first is unknown (0).
+// The years may be numbers or strings. In the pattern headers are compared against, where the years are
+// placeholders, it renders the year placeholder, which matches any years.
+func yearRange(first, last interface{}) (string, error) {
+	for _, v := range []interface{}{first, last} {
+		if _, ok := v.(placeholder); ok {
+			return "{{.Year}}", nil
+		}
+	}
+	from, err := toYear(first)
+	if err != nil {
+		return "", err
+	}
+	to, err := toYear(last)
+	if err != nil {
+		return "", err
+	}
+	if from == 0 {
+		from = to
+	}
+	if to < from {
+		from, to = to, from
+	}
+	return YearRange{First: from, Last: to}.String(), nil
+}
+
+func toYear(v interface{}) (int, error) {
+	switch year := v.(type) {
+	case int:
+		return year, nil
+	case string:
+		if year == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(year)
+	}
+	return 0, fmt.Errorf("year must be a number or string, got %T", v)
+}
+
+func join(sep string, list []string) string {
+	return strings.Join(list, sep)
+}
+
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncs(t *testing.T) {
+	defer os.Unsetenv("SOURCE_DATE_EPOCH")
+	defer os.Unsetenv("LICENSER_TEAM")
+	assert.NoError(t, os.Setenv("SOURCE_DATE_EPOCH", "1777777777"))
+	assert.NoError(t, os.Setenv("LICENSER_TEAM", "platform"))
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{"upper", `{{upper .Owner}}`, "ACME, INC."},
+		{"lower", `{{lower .Owner}}`, "acme, inc."},
+		{"title", `{{title "the widget-maker authors"}}`, "The Widget-Maker Authors"},
+		{"now", `2019-{{now.Year}}`, "2019-2026"},
+		{"date", `{{now | date "January 2006"}}`, "May 2026"},
+		{"env", `{{env "LICENSER_TEAM"}}`, "platform"},
+		{"default missing", `{{.Vars.team | default "core"}}`, "core"},
+		{"default set", `{{.ProjectName | default "widget"}}`, "gadget"},
+		{"default zero", `{{.FirstCommitYear | default 2019}}`, "2019"},
+		{"yearRange", `{{yearRange 2019 .Year.Last}}`, "2019-2021"},
+		{"yearRange same year", `{{yearRange "2021" .Year.Last}}`, "2021"},
+		{"yearRange unknown first", `{{yearRange .FirstCommitYear .Year.Last}}`, "2021"},
+		{"join", `{{join " and " .Owners}}`, "Acme, Inc. and Liam White"},
+		{"indent", `{{indent 2 "a\nb"}}`, "  a\n  b"},
+		{"conditional owners", `{{if gt (len .Owners) 1}}Copyright holders: {{join ", " .Owners}}{{else}}Copyright {{.Owner}}{{end}}`, "Copyright holders: Acme, Inc., Liam White"},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := FromTemplateString(tc.template, "", 2021, "Acme, Inc.")
+			assert.NoError(t, err)
+			h := g.WithOwners("Acme, Inc.", "Liam White").(*Generic).WithContext(FileContext{ProjectName: "gadget"})
+			got, err := Render(h)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
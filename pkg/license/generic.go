@@ -17,65 +17,195 @@ package license
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 	"text/template"
 )
 
-var _ Handler = (*Generic)(nil)
+var (
+	_ Handler    = (*Generic)(nil)
+	_ Comparer   = (*Generic)(nil)
+	_ Dated      = (*Generic)(nil)
+	_ Owned      = (*Generic)(nil)
+	_ Contextual = (*Generic)(nil)
+	_ Renderer   = (*Generic)(nil)
+)
 
 // FromTemplateFile creates a new license handler that uses the given file
-// as the template source.
-func FromTemplateFile(templatePath string, markerText string, year int, owner string) *Generic {
-	tmpl := template.Must(template.ParseFiles(templatePath))
-	return &Generic{Template: tmpl, MarkerText: markerText, Year: year, Owner: owner}
+// as the template source. It returns an error if the file can't be read or isn't a valid template.
+func FromTemplateFile(templatePath string, markerText string, year int, owner string) (*Generic, error) {
+	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(Funcs()).ParseFiles(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid license template %v: %v", templatePath, err)
+	}
+	return newGeneric(tmpl, markerText, year, owner), nil
 }
 
 // FromTemplateString creates a new license handler that uses the given template
-// string to generate the license headers.
-func FromTemplateString(templateStr string, markerText string, year int, owner string) *Generic {
-	tmpl := template.Must(template.New("license").Parse(templateStr))
-	return &Generic{Template: tmpl, MarkerText: markerText, Year: year, Owner: owner}
+// string to generate the license headers. It returns an error if the string isn't a valid template.
+func FromTemplateString(templateStr string, markerText string, year int, owner string) (*Generic, error) {
+	tmpl, err := template.New("license").Funcs(Funcs()).Parse(templateStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid license template: %v", err)
+	}
+	return newGeneric(tmpl, markerText, year, owner), nil
+}
+
+// builtinHandler creates a handler from a template shipped with licenser, which is known to be valid
+func builtinHandler(templateStr string, markerText string, year int, owner string) *Generic {
+	g, err := FromTemplateString(templateStr, markerText, year, owner)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+func newGeneric(tmpl *template.Template, markerText string, year int, owner string) *Generic {
+	return &Generic{Template: tmpl, MarkerText: markerText, Year: Year(year), Owner: owner, Owners: []string{owner}}
 }
 
 // Generic license handler that renders the license from the configured template
 type Generic struct {
-	Year       int
-	Owner      string
+	Year  YearRange
+	Owner string
+	// Owners are all the copyright holders, Owner is the first. Templates that don't range over .Owners
+	// get a copy of the copyright line naming Owner for each of the others.
+	Owners     []string
 	Template   *template.Template
 	MarkerText string
 
+	// Excludes are markers of other licenses, IsPresent is false if any of them are found with MarkerText
+	Excludes []string
+
+	// FileContext describes the file the header is rendered for, its fields can be used in the template
+	FileContext
+
 	licenseCache []byte
 }
 
-// Reader returns a reader populated with the license file prefix
+// noValue is what text/template renders for missing map keys
+const noValue = "<no value>"
+
+// Reader returns a reader populated with the license file prefix. It's empty if the template can't be
+// rendered, use Render to find out why.
 func (g *Generic) Reader() io.Reader {
-	return bytes.NewReader(g.bytes())
+	b, _ := g.Render()
+	return bytes.NewReader(b)
+}
+
+// Render returns the license file prefix, or an error naming the template line and action that failed,
+// e.g. a misspelt field, or the rendered line with a variable that isn't set
+func (g *Generic) Render() ([]byte, error) {
+	if g.licenseCache != nil {
+		return copyBytes(g.licenseCache), nil
+	}
+	b := bytes.NewBuffer([]byte{})
+	if err := g.Template.Execute(b, g); err != nil {
+		return nil, fmt.Errorf("unable to render license template: %v", err)
+	}
+	// Missing map keys, e.g. a misspelt .Vars name, render as <no value> rather than failing. The template
+	// isn't parsed with missingkey=error as that would also fail {{.Vars.team | default "core"}}.
+	for i, line := range strings.Split(b.String(), "\n") {
+		if strings.Contains(line, noValue) {
+			return nil, fmt.Errorf("unable to render license template: line %d of the license uses a variable that isn't set: %q", i+1, line)
+		}
+	}
+	g.licenseCache = []byte(addHolders(b.String(), g.Owners))
+	return copyBytes(g.licenseCache), nil
 }
 
 // IsPresent verifies that the license is present in the reader passed.
+// The marker may be wrapped over several lines.
 func (g *Generic) IsPresent(in io.Reader) bool {
 	inScanner := bufio.NewScanner(in)
-	// Check for presence of license in first 20 lines
-	for i := 0; i < 20; i++ {
-		if inScanner.Scan() {
-			// We should definitely be more thorough here but this will do for now
-			if strings.Contains(inScanner.Text(), g.MarkerText) {
-				return true
-			}
+	// Check for presence of license in first 20 lines, not counting copyright lines
+	// so headers with many holders are still found
+	lines := []string{}
+	for i := 0; i < 20 && inScanner.Scan(); {
+		lines = append(lines, inScanner.Text())
+		if !strings.Contains(strings.ToLower(inScanner.Text()), "copyright") {
+			i++
+		}
+	}
+	// We should definitely be more thorough here but this will do for now
+	text := strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+	for _, exclude := range g.Excludes {
+		if strings.Contains(text, strings.Join(strings.Fields(exclude), " ")) {
+			return false
 		}
 	}
-	return false
+	return strings.Contains(text, strings.Join(strings.Fields(g.MarkerText), " "))
 }
 
-func (g *Generic) bytes() []byte {
-	if g.licenseCache != nil {
-		return copyBytes(g.licenseCache)
+// WithYear returns a copy of the handler rendering the passed year(s)
+func (g *Generic) WithYear(year YearRange) Handler {
+	c := *g
+	c.Year = year
+	c.licenseCache = nil
+	return &c
+}
+
+// WithOwners returns a copy of the handler naming all the passed copyright holders
+func (g *Generic) WithOwners(owners ...string) Handler {
+	c := *g
+	c.Owner, c.Owners = owners[0], owners
+	c.licenseCache = nil
+	return &c
+}
+
+// WithContext returns a copy of the handler rendering the header for the described file
+func (g *Generic) WithContext(ctx FileContext) Handler {
+	c := *g
+	c.FileContext = ctx
+	c.licenseCache = nil
+	return &c
+}
+
+// Compare scores the passed header text against the license, ignoring the year and owner
+func (g *Generic) Compare(text string) Comparison {
+	expected := g.pattern()
+	return Comparison{Score: Similarity(expected, text), Differences: Diff(expected, text)}
+}
+
+// pattern renders the template with placeholders in place of the year, owner and file details
+func (g *Generic) pattern() string {
+	vars := map[string]string{}
+	for k := range g.Vars {
+		vars[k] = "{{.Vars}}"
 	}
 	b := bytes.NewBuffer([]byte{})
-	_ = g.Template.Execute(b, g)
-	g.licenseCache = b.Bytes()
-	return copyBytes(g.licenseCache)
+	err := g.Template.Execute(b, struct {
+		Year                    placeholderYear
+		Owner                   string
+		Owners                  []string
+		Author, FirstCommitYear placeholder
+		FileContext
+	}{
+		Year: placeholderYear{First: "{{.Year}}", Last: "{{.Year}}"}, Owner: "{{.Owner}}", Owners: []string{"{{.Owner}}"},
+		Author: "{{.Author}}", FirstCommitYear: "{{.FirstCommitYear}}",
+		FileContext: FileContext{
+			FileName: "{{.FileName}}", RelPath: "{{.RelPath}}", Language: "{{.Language}}", ProjectName: "{{.ProjectName}}", Vars: vars,
+		},
+	})
+	if err != nil {
+		// A partial pattern would score real headers as barely similar, the marker at least matches
+		return g.MarkerText
+	}
+	return b.String()
+}
+
+// placeholder is a value rendered into the pattern in place of the file's details
+type placeholder string
+
+// placeholderYear stands in for a YearRange in the pattern, so templates can still use .Year.First and .Year.Last
+type placeholderYear struct {
+	First, Last placeholder
+}
+
+func (p placeholderYear) String() string {
+	return string(p.First)
 }
 
 // copyBytes makes copies so consumers of this interface can't mess with our cache
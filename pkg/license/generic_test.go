@@ -17,6 +17,7 @@ package license
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,14 +25,16 @@ import (
 
 func TestReader(t *testing.T) {
 	t.Run("Reader has correct bytes form string", func(t *testing.T) {
-		a := FromTemplateString(license, mark, 2019, "Test")
+		a, err := FromTemplateString(license, mark, 2019, "Test")
+		assert.NoError(t, err)
 		want, _ := ioutil.ReadFile("testdata/apache.golden")
 		got, _ := ioutil.ReadAll(a.Reader())
 		assert.Equal(t, want, got)
 	})
 
 	t.Run("Reader has correct bytes from file ", func(t *testing.T) {
-		a := FromTemplateFile("testdata/apache.golden", mark, 2019, "Test")
+		a, err := FromTemplateFile("testdata/apache.golden", mark, 2019, "Test")
+		assert.NoError(t, err)
 		want, _ := ioutil.ReadFile("testdata/apache.golden")
 		got, _ := ioutil.ReadAll(a.Reader())
 		assert.Equal(t, want, got)
@@ -58,9 +61,132 @@ func TestIsPresent(t *testing.T) {
 	for _, tt := range tests {
 		tc := tt
 		t.Run(tc.name, func(t *testing.T) {
-			a := FromTemplateFile("testdata/apache.golden", mark, 0, "") // The presence check doesn't care about these values
+			a, err := FromTemplateFile("testdata/apache.golden", mark, 0, "") // The presence check doesn't care about these values
+			assert.NoError(t, err)
 			inputReader, _ := os.Open(tc.inputFile)
 			assert.Equal(t, tc.want, a.IsPresent(inputReader))
 		})
 	}
 }
+
+func TestIsPresent_Wrapped(t *testing.T) {
+	a := NewApache20(0, "")
+	wrapped := "Copyright 2019 Test\n\nLicensed under the Apache\nLicense, Version 2.0 (the \"License\");\n"
+	assert.True(t, a.IsPresent(strings.NewReader(wrapped)))
+}
+
+func TestWithOwners(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "copyright line copied for each holder",
+			template: "Copyright (c) {{.Year}} {{.Owner}}. All rights reserved.\n\nMy License\n",
+			want:     "Copyright (c) 2019 Liam White. All rights reserved.\nCopyright (c) 2019 Our Corp. All rights reserved.\n\nMy License\n",
+		},
+		{
+			name:     "template ranges over holders",
+			template: "{{range .Owners}}(c) {{$.Year}} {{.}}\n{{end}}\nMy License\n",
+			want:     "(c) 2019 Liam White\n(c) 2019 Our Corp\n\nMy License\n",
+		},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := FromTemplateString(tc.template, "My License", 2019, "Liam White")
+			assert.NoError(t, err)
+			h := g.WithOwners("Liam White", "Our Corp")
+			got, _ := ioutil.ReadAll(h.Reader())
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestIsPresent_ManyHolders(t *testing.T) {
+	header := strings.Repeat("Copyright 2019 Someone\n", 25) + "Licensed under the Apache License, Version 2.0\n"
+	assert.True(t, NewApache20(2019, "").IsPresent(strings.NewReader(header)))
+}
+
+func TestWithContext(t *testing.T) {
+	template := "Copyright {{.Year}} {{.Owner}}\n\n{{.RelPath}} is part of {{.ProjectName}} ({{.Vars.team}}), first written by {{.Author}} in {{.FirstCommitYear}}.\n{{if eq .Language \"golang\"}}Go only.\n{{end}}"
+	h, err := FromTemplateString(template, "is part of", 2019, "Test")
+	assert.NoError(t, err)
+	histories := 0
+	ctx := FileContext{RelPath: "pkg/main.go", Language: "golang", ProjectName: "widget", Vars: map[string]string{"team": "core"},
+		History: func() (int, string) {
+			histories++
+			return 2017, "Ada"
+		}}
+	got, _ := ioutil.ReadAll(h.WithContext(ctx).Reader())
+	assert.Equal(t, "Copyright 2019 Test\n\npkg/main.go is part of widget (core), first written by Ada in 2017.\nGo only.\n", string(got))
+
+	ctx.Language, ctx.History = "yaml", nil
+	got, _ = ioutil.ReadAll(h.WithContext(ctx).Reader())
+	assert.Equal(t, "Copyright 2019 Test\n\npkg/main.go is part of widget (core), first written by  in 0.\n", string(got))
+
+	assert.Equal(t, 2, histories)
+
+	// Headers for other files are still recognised as the license
+	assert.True(t, h.WithContext(ctx).(*Generic).Compare("Copyright 2020 Other\n\ncmd/main.go is part of widget (core), first written by Ada in 2017.\n").Score >= ClassifyThreshold)
+}
+
+func TestTemplateErrors(t *testing.T) {
+	t.Run("Missing template file", func(t *testing.T) {
+		_, err := FromTemplateFile("testdata/missing.tmpl", mark, 2019, "Test")
+		assert.Error(t, err)
+	})
+
+	t.Run("Parse error names the line", func(t *testing.T) {
+		_, err := FromTemplateString("Copyright {{.Year}} {{.Owner}}\n\n{{if .Owner}}Licensed\n", mark, 2019, "Test")
+		assert.EqualError(t, err, "invalid license template: template: license:4: unexpected EOF")
+	})
+
+	t.Run("Unknown function", func(t *testing.T) {
+		_, err := FromTemplateString("Copyright {{.Year}} {{shout .Owner}}\n", mark, 2019, "Test")
+		assert.EqualError(t, err, `invalid license template: template: license:1: function "shout" not defined`)
+	})
+
+	t.Run("Render error names the line and action", func(t *testing.T) {
+		g, err := FromTemplateString("Copyright {{.Year}}\n{{.Onwer}}\n", mark, 2019, "Test")
+		assert.NoError(t, err)
+		_, err = g.Render()
+		assert.EqualError(t, err, `unable to render license template: template: license:2:2: executing "license" at <.Onwer>: can't evaluate field Onwer in type *license.Generic`)
+		_, err = Render(g)
+		assert.Error(t, err)
+	})
+
+	t.Run("Variable that isn't set", func(t *testing.T) {
+		g, err := FromTemplateString("Copyright {{.Year}} {{.Owner}} team {{.Vars.teem}}\n", mark, 2019, "Test")
+		assert.NoError(t, err)
+		g = g.WithContext(FileContext{Vars: map[string]string{"team": "core"}}).(*Generic)
+		_, err = g.Render()
+		assert.EqualError(t, err, `unable to render license template: line 1 of the license uses a variable that isn't set: "Copyright 2019 Test team <no value>"`)
+	})
+}
+
+func TestCompare_YearFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"last year", "Copyright 2019-{{.Year.Last}} {{.Owner}}\n\nThis file is licensed under the Widget Public License.\n"},
+		{"year range", "Copyright {{yearRange .FirstCommitYear .Year.Last}} {{.Owner}}\n\nThis file is licensed under the Widget Public License.\n"},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := FromTemplateString(tc.template, "Widget Public License", 2026, "Test")
+			assert.NoError(t, err)
+			comparison := g.Compare("Copyright 2019-2026 Acme\n\nThis file is licensed under the Widget Public Licence.\n")
+			assert.True(t, comparison.Score > 0.9, "score %v", comparison.Score)
+		})
+	}
+
+	t.Run("falls back to the marker", func(t *testing.T) {
+		g, err := FromTemplateString("Copyright {{.Year}} {{index .Owners 3}}\n\nWidget Public License\n", "Widget Public License", 2026, "Test")
+		assert.NoError(t, err)
+		assert.Equal(t, "Widget Public License", g.pattern())
+	})
+}
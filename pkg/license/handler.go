@@ -14,7 +14,9 @@
 
 package license
 
-import "io"
+import (
+	"io"
+)
 
 // Handler is the interface required to implement a license
 type Handler interface {
@@ -24,3 +26,27 @@ type Handler interface {
 	// IsPresent returns true if it can find the license in the passed reader
 	IsPresent(in io.Reader) bool
 }
+
+// Renderer is a Handler whose rendering can fail, e.g. a template using a field that doesn't exist
+type Renderer interface {
+	Handler
+
+	// Render returns the license bytes that will be prepended to files, or why they couldn't be rendered
+	Render() ([]byte, error)
+}
+
+// Render returns the license bytes the handler prepends to files, or an error if it's a Renderer that fails
+func Render(h Handler) ([]byte, error) {
+	if r, ok := h.(Renderer); ok {
+		return r.Render()
+	}
+	return io.ReadAll(h.Reader())
+}
+
+// Owned is a Handler whose copyright holders can be changed
+type Owned interface {
+	Handler
+
+	// WithOwners returns a copy of the handler naming all the passed copyright holders
+	WithOwners(owners ...string) Handler
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	holderMarkers     = regexp.MustCompile(`(?i)^(?:(?:copyright|\(c\)|©)\s*)+`)
+	holderPunctuation = regexp.MustCompile(`[^\pL\pN&+]+`)
+
+	// corporateSuffixes are dropped from the end of holders, so "Acme", "Acme Inc." and "ACME Incorporated" match
+	corporateSuffixes = map[string]bool{
+		"ab": true, "ag": true, "bv": true, "co": true, "company": true, "corp": true, "corporation": true,
+		"gmbh": true, "inc": true, "incorporated": true, "limited": true, "llc": true, "llp": true,
+		"lp": true, "ltd": true, "nv": true, "plc": true, "pty": true, "sa": true, "sarl": true,
+	}
+)
+
+// NormalizeHolder reduces a copyright holder to a form in which harmless variations compare equal:
+// case, (c), © and Copyright markers, punctuation and corporate suffixes such as "Inc." are all ignored.
+func NormalizeHolder(holder string) string {
+	holder = holderMarkers.ReplaceAllString(strings.TrimSpace(holder), "")
+	words := strings.Fields(strings.ToLower(holderPunctuation.ReplaceAllString(holder, " ")))
+	for len(words) > 1 && corporateSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// Holders compares copyright holders, treating configured aliases as the same legal entity
+// as their canonical name. A nil Holders only applies NormalizeHolder.
+type Holders struct {
+	canonical map[string]string
+}
+
+// NewHolders creates Holders from aliases keyed by canonical name, e.g. {"Acme, Inc.": ["Acme Widgets"]}
+func NewHolders(aliases map[string][]string) *Holders {
+	h := &Holders{canonical: map[string]string{}}
+	for canonical, names := range aliases {
+		h.canonical[NormalizeHolder(canonical)] = canonical
+		for _, name := range names {
+			h.canonical[NormalizeHolder(name)] = canonical
+		}
+	}
+	return h
+}
+
+// Canonical returns the canonical name of the holder if it's an alias, or the holder as it is otherwise
+func (h *Holders) Canonical(holder string) string {
+	if h != nil {
+		if canonical, ok := h.canonical[NormalizeHolder(holder)]; ok {
+			return canonical
+		}
+	}
+	return strings.TrimSpace(holder)
+}
+
+// Same returns true if both holders refer to the same legal entity
+func (h *Holders) Same(a, b string) bool {
+	return NormalizeHolder(h.Canonical(a)) == NormalizeHolder(h.Canonical(b))
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeHolder(t *testing.T) {
+	for _, holder := range []string{"Acme", "Acme Inc", "Acme, Inc.", "ACME Incorporated", "(c) Acme", "© Acme", "Copyright (c) Acme Ltd."} {
+		t.Run(holder, func(t *testing.T) {
+			assert.Equal(t, "acme", NormalizeHolder(holder))
+		})
+	}
+	assert.Equal(t, "ltd", NormalizeHolder("Ltd."))
+	assert.Equal(t, "acme widgets", NormalizeHolder("Acme Widgets Co."))
+	assert.Equal(t, "at&t", NormalizeHolder("AT&T Inc."))
+}
+
+func TestHolders(t *testing.T) {
+	h := NewHolders(map[string][]string{"Acme, Inc.": {"Acme Widgets", "ACME Europe GmbH"}})
+	assert.Equal(t, "Acme, Inc.", h.Canonical("acme widgets"))
+	assert.Equal(t, "Acme, Inc.", h.Canonical("Acme Europe"))
+	assert.Equal(t, "Other Corp", h.Canonical(" Other Corp "))
+	assert.True(t, h.Same("Acme Widgets Ltd", "(c) Acme Inc"))
+	assert.False(t, h.Same("Acme Widgets", "Other Corp"))
+
+	var none *Holders
+	assert.True(t, none.Same("Acme Inc.", "ACME"))
+	assert.False(t, none.Same("Acme Widgets", "Acme"))
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"sort"
+)
+
+// HolderSummary describes the files a copyright holder appears in
+type HolderSummary struct {
+	// Holder is the canonical name, or the most common spelling, of the holder
+	Holder string `json:"holder"`
+
+	// Files is the number of files with a notice naming the holder
+	Files int `json:"files"`
+
+	// Years is the span of years across the holder's notices, e.g. "2019-2026". Empty if none have years.
+	Years string `json:"years,omitempty"`
+
+	// Examples are some of the files, in path order
+	Examples []string `json:"examples"`
+}
+
+// Inventory gathers the copyright holders of many files, grouping notices naming the same holder
+// in different forms
+type Inventory struct {
+	holders *Holders
+	entries map[string]*inventoryEntry
+}
+
+type inventoryEntry struct {
+	spellings map[string]int
+	files     map[string]bool
+	years     YearRange
+}
+
+// NewInventory creates an empty inventory that treats the aliases in holders as the same holder
+func NewInventory(holders *Holders) *Inventory {
+	return &Inventory{holders: holders, entries: map[string]*inventoryEntry{}}
+}
+
+// Add records the copyright notices found in the file at path
+func (i *Inventory) Add(path string, notices []Copyright) {
+	for _, c := range notices {
+		if c.Holder == "" {
+			continue
+		}
+		holder := i.holders.Canonical(c.Holder)
+		key := NormalizeHolder(holder)
+		e, ok := i.entries[key]
+		if !ok {
+			e = &inventoryEntry{spellings: map[string]int{}, files: map[string]bool{}}
+			i.entries[key] = e
+		}
+		e.spellings[holder]++
+		e.files[path] = true
+		if span := c.Span(); span.First != 0 {
+			if e.years.First == 0 || span.First < e.years.First {
+				e.years.First = span.First
+			}
+			if span.Last > e.years.Last {
+				e.years.Last = span.Last
+			}
+		}
+	}
+}
+
+// Summary returns each holder with up to examples of its files, ordered by number of files, most first
+func (i *Inventory) Summary(examples int) []HolderSummary {
+	result := []HolderSummary{}
+	for _, e := range i.entries {
+		s := HolderSummary{Holder: e.name(), Files: len(e.files), Examples: []string{}}
+		if e.years.First != 0 {
+			s.Years = e.years.String()
+		}
+		paths := []string{}
+		for path := range e.files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		if len(paths) > examples {
+			paths = paths[:examples]
+		}
+		s.Examples = append(s.Examples, paths...)
+		result = append(result, s)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Files != result[b].Files {
+			return result[a].Files > result[b].Files
+		}
+		return result[a].Holder < result[b].Holder
+	})
+	return result
+}
+
+// name is the most common spelling of the holder, alphabetically first if there's a tie
+func (e *inventoryEntry) name() string {
+	name := ""
+	for spelling, count := range e.spellings {
+		if name == "" || count > e.spellings[name] || (count == e.spellings[name] && spelling < name) {
+			name = spelling
+		}
+	}
+	return name
+}
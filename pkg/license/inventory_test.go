@@ -0,0 +1,37 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInventory(t *testing.T) {
+	inventory := NewInventory(NewHolders(map[string][]string{"Acme, Inc.": {"Acme Widgets"}}))
+	inventory.Add("a.go", []Copyright{{Years: "2019", Holder: "Acme Inc"}, {Years: "2020", Holder: "Other Corp"}})
+	inventory.Add("b.go", []Copyright{{Years: "2021-2023", Holder: "Acme Widgets"}, {Holder: "OTHER CORP"}})
+	inventory.Add("d.go", []Copyright{{Holder: "Other Corp"}})
+	inventory.Add("c.go", []Copyright{{Holder: "Acme Inc"}, {Holder: ""}})
+	inventory.Add("c.go", []Copyright{{Years: "2018", Holder: "acme inc."}})
+
+	want := []HolderSummary{
+		// Aliases are reported by their canonical name, other holders by their most common spelling
+		{Holder: "Acme, Inc.", Files: 3, Years: "2018-2023", Examples: []string{"a.go", "b.go"}},
+		{Holder: "Other Corp", Files: 3, Years: "2020", Examples: []string{"a.go", "b.go"}},
+	}
+	assert.Equal(t, want, inventory.Summary(2))
+}
@@ -0,0 +1,128 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// Builtin is a standard license header shipped with licenser
+type Builtin struct {
+	// ID is the SPDX license identifier, e.g. "MIT"
+	ID string
+
+	// Name is the full name of the license
+	Name string
+
+	// Marker is the text used to detect the license when no other marker is given
+	Marker string
+
+	// Excludes are the markers of licenses whose text contains this one's, e.g. BSD-3-Clause's for
+	// BSD-2-Clause. A header containing any of them isn't this license.
+	Excludes []string
+
+	// Template is the text/template source of the license header
+	Template string
+}
+
+// Handler creates a license handler rendering the builtin header
+func (b Builtin) Handler(year int, owner string) *Generic {
+	g := builtinHandler(b.Template, b.Marker, year, owner)
+	g.Excludes = b.Excludes
+	return g
+}
+
+const (
+	// bsd3Clause is the start of BSD-3-Clause's third clause, short enough to stay on one line of the header
+	bsd3Clause = "Neither the name of the copyright holder"
+	gnuOrLater = "either version %s of the License, or (at your option) any later version"
+	gnuOnly    = "version %s of the License."
+)
+
+var builtins = map[string]Builtin{
+	"Apache-2.0":        {Name: "Apache License 2.0", Marker: mark, Template: license},
+	"MIT":               {Name: "MIT License", Marker: "Permission is hereby granted, free of charge"},
+	"BSD-2-Clause":      {Name: `BSD 2-Clause "Simplified" License`, Marker: "Redistribution and use in source and binary forms", Excludes: []string{bsd3Clause}},
+	"BSD-3-Clause":      {Name: `BSD 3-Clause "New" or "Revised" License`, Marker: "Neither the name of the copyright holder nor the names of its contributors"},
+	"ISC":               {Name: "ISC License", Marker: "Permission to use, copy, modify, and/or distribute this software for any purpose"},
+	"MPL-2.0":           {Name: "Mozilla Public License 2.0", Marker: "subject to the terms of the Mozilla Public License, v. 2.0"},
+	"EPL-2.0":           {Name: "Eclipse Public License 2.0", Marker: "terms of the Eclipse Public License 2.0"},
+	"Unlicense":         {Name: "The Unlicense", Marker: "This is free and unencumbered software released into the public domain"},
+	"CC-BY-4.0":         {Name: "Creative Commons Attribution 4.0 International", Marker: "licensed under the Creative Commons Attribution 4.0 International License"},
+	"GPL-2.0-only":      {Name: "GNU General Public License v2.0 only", Marker: gnu("General", gnuOnly, "2")},
+	"GPL-2.0-or-later":  {Name: "GNU General Public License v2.0 or later", Marker: gnu("General", gnuOrLater, "2")},
+	"GPL-3.0-only":      {Name: "GNU General Public License v3.0 only", Marker: gnu("General", gnuOnly, "3")},
+	"GPL-3.0-or-later":  {Name: "GNU General Public License v3.0 or later", Marker: gnu("General", gnuOrLater, "3")},
+	"LGPL-2.1-only":     {Name: "GNU Lesser General Public License v2.1 only", Marker: gnu("Lesser General", gnuOnly, "2.1")},
+	"LGPL-2.1-or-later": {Name: "GNU Lesser General Public License v2.1 or later", Marker: gnu("Lesser General", gnuOrLater, "2.1")},
+	"LGPL-3.0-only":     {Name: "GNU Lesser General Public License v3.0 only", Marker: gnu("Lesser General", gnuOnly, "3")},
+	"LGPL-3.0-or-later": {Name: "GNU Lesser General Public License v3.0 or later", Marker: gnu("Lesser General", gnuOrLater, "3")},
+	"AGPL-3.0-only":     {Name: "GNU Affero General Public License v3.0 only", Marker: gnu("Affero General", gnuOnly, "3")},
+	"AGPL-3.0-or-later": {Name: "GNU Affero General Public License v3.0 or later", Marker: gnu("Affero General", gnuOrLater, "3")},
+}
+
+// gnu builds the marker for a GNU license, these only differ by name and version wording
+func gnu(name, wording, version string) string {
+	return fmt.Sprintf("GNU %s Public License as published by the Free Software Foundation, %s", name, fmt.Sprintf(wording, version))
+}
+
+func init() {
+	for id, b := range builtins {
+		b.ID = id
+		if b.Template == "" {
+			contents, err := templates.ReadFile("templates/" + id + ".tmpl")
+			if err != nil {
+				panic(fmt.Sprintf("missing template for builtin license %v: %v", id, err))
+			}
+			b.Template = string(contents)
+		}
+		builtins[id] = b
+	}
+}
+
+// LookupBuiltin returns the builtin license with the passed SPDX ID, ignoring case
+func LookupBuiltin(id string) (Builtin, bool) {
+	for builtinID, b := range builtins {
+		if strings.EqualFold(builtinID, id) {
+			return b, true
+		}
+	}
+	return Builtin{}, false
+}
+
+// Builtins returns all the builtin licenses sorted by SPDX ID
+func Builtins() []Builtin {
+	result := make([]Builtin, 0, len(builtins))
+	for _, b := range builtins {
+		result = append(result, b)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// BuiltinIDs returns the SPDX IDs of all the builtin licenses
+func BuiltinIDs() []string {
+	ids := []string{}
+	for _, b := range Builtins() {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
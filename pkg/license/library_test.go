@@ -0,0 +1,45 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltins(t *testing.T) {
+	for _, b := range Builtins() {
+		builtin := b
+		t.Run(builtin.ID, func(t *testing.T) {
+			rendered, _ := ioutil.ReadAll(builtin.Handler(2019, "Test").Reader())
+			assert.NotEmpty(t, rendered)
+			for _, other := range Builtins() {
+				present := other.Handler(0, "").IsPresent(builtin.Handler(2019, "Test").Reader())
+				assert.Equal(t, other.ID == builtin.ID, present, "%v marker in %v header", other.ID, builtin.ID)
+			}
+		})
+	}
+}
+
+func TestLookupBuiltin(t *testing.T) {
+	b, ok := LookupBuiltin("apache-2.0")
+	assert.True(t, ok)
+	assert.Equal(t, "Apache-2.0", b.ID)
+
+	_, ok = LookupBuiltin("Not-A-License")
+	assert.False(t, ok)
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// OwnerRename maps copyright holders matching a name or pattern to a new holder
+type OwnerRename struct {
+	from    *regexp.Regexp
+	to      string
+	literal bool
+}
+
+// ExactRename renames the holder named exactly from. A trailing period is optional, as it's
+// often run into the suffix, e.g. "Acme Inc. All rights reserved."
+func ExactRename(from, to string) OwnerRename {
+	from = strings.TrimSuffix(strings.TrimSpace(from), ".")
+	return OwnerRename{from: regexp.MustCompile("^" + regexp.QuoteMeta(from) + `\.?$`), to: to, literal: true}
+}
+
+// RegexRename renames holders wholly matching the regular expression. The new holder may refer to
+// submatches, e.g. "$1".
+func RegexRename(pattern, to string) (OwnerRename, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return OwnerRename{}, fmt.Errorf("invalid owner pattern %q: %v", pattern, err)
+	}
+	return OwnerRename{from: re, to: to}, nil
+}
+
+// OwnerRenames is a table of renames, the first matching rename applies
+type OwnerRenames []OwnerRename
+
+// Rename returns the new name for the holder, or false if no rename matches it
+func (r OwnerRenames) Rename(holder string) (string, bool) {
+	holder = strings.TrimSpace(holder)
+	for _, rename := range r {
+		if !rename.from.MatchString(holder) {
+			continue
+		}
+		if rename.literal {
+			return rename.to, true
+		}
+		return rename.from.ReplaceAllString(holder, rename.to), true
+	}
+	return holder, false
+}
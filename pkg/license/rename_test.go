@@ -0,0 +1,52 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnerRenames_Rename(t *testing.T) {
+	regex, err := RegexRename(`OldCo (\w+) GmbH`, "NewCo $1 GmbH")
+	assert.NoError(t, err)
+	renames := OwnerRenames{ExactRename("OldCo Inc.", "NewCo $LLC"), regex}
+
+	tests := []struct {
+		holder string
+		want   string
+		wantOK bool
+	}{
+		{"OldCo Inc.", "NewCo $LLC", true},
+		{"OldCo Inc", "NewCo $LLC", true},
+		{"OldCo Incorporated", "OldCo Incorporated", false},
+		{"OldCo Europe GmbH", "NewCo Europe GmbH", true},
+		{"Not OldCo Europe GmbH", "Not OldCo Europe GmbH", false},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.holder, func(t *testing.T) {
+			got, ok := renames.Rename(tc.holder)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRegexRename_Invalid(t *testing.T) {
+	_, err := RegexRename("OldCo (", "NewCo")
+	assert.Error(t, err)
+}
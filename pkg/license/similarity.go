@@ -0,0 +1,153 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Comparer is implemented by handlers that can score how closely some text matches their license
+type Comparer interface {
+	// Compare scores the passed (uncommented) header text against the license
+	Compare(text string) Comparison
+}
+
+// Comparison is the result of comparing a header against a license
+type Comparison struct {
+	// Score is the token similarity between 0 (nothing in common) and 1 (identical)
+	Score float64
+
+	// Differences are the lines that don't match, expected lines are prefixed with "- " and found lines with "+ "
+	Differences []string
+}
+
+// tokenPattern splits text into words and punctuation, keeping template placeholders whole
+var tokenPattern = regexp.MustCompile(`\{\{\.\w+\}\}|\w+|[^\s\w]`)
+
+// placeholderPattern matches a template placeholder left in the expected text, which matches any run of tokens
+var placeholderPattern = regexp.MustCompile(`^\{\{\.\w+\}\}$`)
+
+// Similarity returns the token level similarity between expected and found.
+// Placeholders such as {{.Owner}} in expected match any (possibly empty) run of tokens in found.
+func Similarity(expected, found string) float64 {
+	want, got := tokenize(expected), tokenize(found)
+	size := len(got)
+	if fixed := countFixed(want); fixed > size {
+		size = fixed
+	}
+	if size == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(want, got))/float64(size)
+}
+
+// Diff returns the lines of expected and found that don't match each other
+func Diff(expected, found string) []string {
+	want, got := splitNonEmpty(expected), splitNonEmpty(found)
+
+	// Longest common subsequence of lines, where lines are equal if they match token for token
+	lcs := make([][]int, len(want)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(got)+1)
+	}
+	for i := len(want) - 1; i >= 0; i-- {
+		for j := len(got) - 1; j >= 0; j-- {
+			if linesMatch(want[i], got[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	diff := []string{}
+	i, j := 0, 0
+	for i < len(want) || j < len(got) {
+		switch {
+		case i < len(want) && j < len(got) && linesMatch(want[i], got[j]):
+			i++
+			j++
+		case j == len(got) || (i < len(want) && lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, "- "+want[i])
+			i++
+		default:
+			diff = append(diff, "+ "+got[j])
+			j++
+		}
+	}
+	return diff
+}
+
+func linesMatch(expected, found string) bool {
+	return editDistance(tokenize(expected), tokenize(found)) == 0
+}
+
+func tokenize(text string) []string {
+	return tokenPattern.FindAllString(text, -1)
+}
+
+func countFixed(tokens []string) int {
+	n := 0
+	for _, t := range tokens {
+		if !placeholderPattern.MatchString(t) {
+			n++
+		}
+	}
+	return n
+}
+
+// editDistance is the Levenshtein distance between two token lists, where placeholders in want are free wildcards
+func editDistance(want, got []string) int {
+	prev := make([]int, len(got)+1)
+	curr := make([]int, len(got)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(want); i++ {
+		wildcard := placeholderPattern.MatchString(want[i-1])
+		if wildcard {
+			curr[0] = prev[0]
+		} else {
+			curr[0] = prev[0] + 1
+		}
+		for j := 1; j <= len(got); j++ {
+			if wildcard {
+				// Either the wildcard matches nothing more or it swallows this token
+				curr[j] = min(prev[j], curr[j-1])
+				continue
+			}
+			cost := 1
+			if want[i-1] == got[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(got)]
+}
+
+func splitNonEmpty(text string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimRight(line, " \t"))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		found    string
+		want     float64
+	}{
+		{"identical", "Copyright 2019 Test", "Copyright 2019 Test", 1},
+		{"placeholder matches many tokens", "Copyright {{.Year}} {{.Owner}}", "Copyright 2019 Liam White", 1},
+		{"placeholder matches nothing", "Copyright {{.Year}} {{.Owner}}", "Copyright", 1},
+		{"one token differs", "a b c d", "a b x d", 0.75},
+		{"nothing in common", "a b", "c d", 0},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, Similarity(tc.expected, tc.found), 0.001)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	got := Diff("one\ntwo\nthree\n", "one\ntoo\nthree\nfour\n")
+	assert.Equal(t, []string{"- two", "+ too", "+ four"}, got)
+}
+
+func TestCompare(t *testing.T) {
+	a := NewApache20(0, "")
+	golden, _ := ioutil.ReadFile("testdata/apache.golden")
+
+	t.Run("Matching license ignores year and owner", func(t *testing.T) {
+		got := a.Compare(string(golden))
+		assert.Equal(t, 1.0, got.Score)
+		assert.Empty(t, got.Differences)
+	})
+
+	t.Run("Typo is reported", func(t *testing.T) {
+		typo := strings.Replace(string(golden), "Apache License", "Apache Licence", 1)
+		got := a.Compare(typo)
+		assert.True(t, got.Score > 0.9 && got.Score < 1)
+		assert.Len(t, got.Differences, 2)
+	})
+}
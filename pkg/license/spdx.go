@@ -0,0 +1,113 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// IdentifierTag precedes the license expression in an SPDX short header
+	IdentifierTag = "SPDX-License-Identifier:"
+
+	// CopyrightTag precedes the copyright holder in an SPDX short header
+	CopyrightTag = "SPDX-FileCopyrightText:"
+)
+
+var (
+	_ Dated = (*SPDX)(nil)
+	_ Owned = (*SPDX)(nil)
+)
+
+// NewSPDX creates a license handler that renders an SPDX short header for the passed license expression
+func NewSPDX(expression string, year int, owner string) *SPDX {
+	return &SPDX{Expression: expression, Year: Year(year), Owner: owner, Owners: []string{owner}}
+}
+
+// SPDX license handler that renders SPDX-FileCopyrightText and SPDX-License-Identifier lines
+// rather than the full license text
+type SPDX struct {
+	Year  YearRange
+	Owner string
+	// Owners are all the copyright holders, Owner is the first. Each gets its own SPDX-FileCopyrightText line.
+	Owners     []string
+	Expression string
+}
+
+// Reader returns a reader populated with the short header
+func (s *SPDX) Reader() io.Reader {
+	b := bytes.NewBuffer([]byte{})
+	for _, owner := range s.Owners {
+		_, _ = fmt.Fprintf(b, "%s %s %s\n", CopyrightTag, s.Year, owner)
+	}
+	_, _ = fmt.Fprintf(b, "%s %s\n", IdentifierTag, s.Expression)
+	return b
+}
+
+// WithYear returns a copy of the handler rendering the passed year(s)
+func (s *SPDX) WithYear(year YearRange) Handler {
+	c := *s
+	c.Year = year
+	return &c
+}
+
+// WithOwners returns a copy of the handler naming all the passed copyright holders
+func (s *SPDX) WithOwners(owners ...string) Handler {
+	c := *s
+	c.Owner, c.Owners = owners[0], owners
+	return &c
+}
+
+// IsPresent returns true if the reader has an SPDX-License-Identifier in its first 20 lines
+// whose expression is the same as the handler's.
+func (s *SPDX) IsPresent(in io.Reader) bool {
+	found, ok := FindIdentifier(in)
+	if !ok {
+		return false
+	}
+	foundExpr, err := ParseExpression(found)
+	if err != nil {
+		return false
+	}
+	expected, err := ParseExpression(s.Expression)
+	if err != nil {
+		return false
+	}
+	return foundExpr.String() == expected.String()
+}
+
+// FindIdentifier returns the license expression following the first SPDX-License-Identifier
+// tag in the first 20 lines of the reader.
+func FindIdentifier(in io.Reader) (string, bool) {
+	scanner := bufio.NewScanner(in)
+	for i := 0; i < 20 && scanner.Scan(); i++ {
+		line := scanner.Text()
+		idx := strings.Index(line, IdentifierTag)
+		if idx < 0 {
+			continue
+		}
+		expression := strings.TrimSpace(line[idx+len(IdentifierTag):])
+		// Drop the end of a block comment that shares the line
+		for _, suffix := range []string{"*/", "-->", "*)"} {
+			expression = strings.TrimSpace(strings.TrimSuffix(expression, suffix))
+		}
+		return expression, true
+	}
+	return "", false
+}
@@ -0,0 +1,49 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSPDX_Reader(t *testing.T) {
+	got, _ := ioutil.ReadAll(NewSPDX("Apache-2.0", 2019, "Test").Reader())
+	assert.Equal(t, "SPDX-FileCopyrightText: 2019 Test\nSPDX-License-Identifier: Apache-2.0\n", string(got))
+}
+
+func TestSPDX_IsPresent(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		input      string
+		want       bool
+	}{
+		{"Identifier matches", "Apache-2.0", "// SPDX-License-Identifier: Apache-2.0\npackage main\n", true},
+		{"Expression matches ignoring case and spacing", "MIT OR Apache-2.0", "/* SPDX-License-Identifier: mit or  apache-2.0 */\n", true},
+		{"Expression with exception", "GPL-2.0-only WITH Classpath-exception-2.0", "# SPDX-License-Identifier: GPL-2.0-only WITH Classpath-exception-2.0\n", true},
+		{"Different license", "Apache-2.0", "// SPDX-License-Identifier: MIT\n", false},
+		{"No identifier", "Apache-2.0", "// Licensed under the Apache License, Version 2.0\n", false},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, NewSPDX(tc.expression, 0, "").IsPresent(strings.NewReader(tc.input)))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// YearRange is the span of years a copyright notice covers, written as "2019" or "2019-2026"
+type YearRange struct {
+	First int
+	Last  int
+}
+
+// Year returns a range covering the single year passed
+func Year(year int) YearRange {
+	return YearRange{First: year, Last: year}
+}
+
+// ParseYearRange parses a single year, e.g. "2019", or a range, e.g. "2019-2026"
+func ParseYearRange(s string) (YearRange, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), "-", 2)
+	first, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return YearRange{}, fmt.Errorf("invalid year %q, must be a year such as 2019 or a range such as 2019-2026", s)
+	}
+	r := Year(first)
+	if len(parts) == 2 {
+		if r.Last, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil {
+			return YearRange{}, fmt.Errorf("invalid year %q, must be a year such as 2019 or a range such as 2019-2026", s)
+		}
+		if r.Last < r.First {
+			return YearRange{}, fmt.Errorf("invalid year range %q, the last year is before the first", s)
+		}
+	}
+	return r, nil
+}
+
+// String formats the range, collapsing it to a single year if it starts and ends in the same year
+func (r YearRange) String() string {
+	if r.Last <= r.First {
+		return strconv.Itoa(r.First)
+	}
+	return fmt.Sprintf("%d-%d", r.First, r.Last)
+}
+
+// Dated is a Handler whose copyright year can be set per file
+type Dated interface {
+	Handler
+
+	// WithYear returns a copy of the handler rendering the passed year(s)
+	WithYear(year YearRange) Handler
+}
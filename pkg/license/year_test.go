@@ -0,0 +1,62 @@
+// Copyright 2026 Liam White
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseYearRange(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    YearRange
+		wantErr bool
+	}{
+		{"2019", YearRange{2019, 2019}, false},
+		{"2019-2026", YearRange{2019, 2026}, false},
+		{" 2019 - 2026 ", YearRange{2019, 2026}, false},
+		{"2026-2019", YearRange{}, true},
+		{"now", YearRange{}, true},
+		{"2019-", YearRange{}, true},
+	}
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseYearRange(tc.in)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestYearRange_String(t *testing.T) {
+	assert.Equal(t, "2019", Year(2019).String())
+	assert.Equal(t, "2019-2026", YearRange{2019, 2026}.String())
+}
+
+func TestWithYear(t *testing.T) {
+	h := NewApache20(2019, "Liam White")
+	_, _ = ioutil.ReadAll(h.Reader())
+	dated, _ := ioutil.ReadAll(h.WithYear(YearRange{2019, 2026}).Reader())
+	assert.Contains(t, string(dated), "Copyright 2019-2026 Liam White")
+	original, _ := ioutil.ReadAll(h.Reader())
+	assert.Contains(t, string(original), "Copyright 2019 Liam White")
+
+	short, _ := ioutil.ReadAll(NewSPDX("MIT", 2019, "Liam White").WithYear(YearRange{2019, 2026}).Reader())
+	assert.Contains(t, string(short), CopyrightTag+" 2019-2026 Liam White")
+}
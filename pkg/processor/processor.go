@@ -31,6 +31,10 @@ import (
 // licenserignoreFile is a name for *ignore files specific to licenser.
 const licenserignoreFile = ".licenserignore"
 
+// maxConcurrentFiles is how many files are visited at once, so large trees don't open every file, and
+// start a git process for each, together
+const maxConcurrentFiles = 32
+
 // Processor finds all valid files and passes them to a file mutator to be handled
 type Processor struct {
 	startDirectory string
@@ -38,6 +42,7 @@ type Processor struct {
 	mutator   file.Licenser
 	visitFunc func(path string, dryRun bool) bool
 	wg        sync.WaitGroup
+	slots     chan struct{}
 
 	dryRun  bool
 	success bool
@@ -49,13 +54,21 @@ type Processor struct {
 
 // New creates a new file processor starting the the passed startDirectory
 // and using the passed license to apply and verify files
-func New(startDirectory string, license license.Handler) *Processor {
+func New(startDirectory string, license license.Handler, opts ...file.Option) *Processor {
+	p := NewWalker(startDirectory)
+	p.mutator = mutator.New(license, opts...)
+	return p
+}
+
+// NewWalker creates a file processor starting at the passed startDirectory
+// that only walks files, for use with Walk
+func NewWalker(startDirectory string) *Processor {
 	return &Processor{
 		startDirectory:         startDirectory,
-		mutator:                mutator.New(license),
 		skipListGitIgnore:      buildGitIgnoreSkip(startDirectory),
 		skipListLicenserIgnore: buildLicenserIgnoreSkip(startDirectory),
 		skipListExtension:      buildExtensionSkip(),
+		slots:                  make(chan struct{}, maxConcurrentFiles),
 	}
 }
 
@@ -72,6 +85,37 @@ func (p *Processor) Verify(recurse bool) bool {
 	return p.run(recurse)
 }
 
+// Remove tells the mutator to strip the license from all walked files
+func (p *Processor) Remove(recurse, dryRun bool) bool {
+	p.dryRun = dryRun
+	p.visitFunc = p.mutator.Remove
+	return p.run(recurse)
+}
+
+// UpdateYear tells the mutator to bring the copyright years up to date in all walked files
+func (p *Processor) UpdateYear(recurse, replace, dryRun bool) bool {
+	p.dryRun = dryRun
+	p.visitFunc = func(path string, dryRun bool) bool {
+		return p.mutator.UpdateYear(path, replace, dryRun)
+	}
+	return p.run(recurse)
+}
+
+// Relicense tells the mutator to replace the from license header in all walked files
+func (p *Processor) Relicense(from license.Handler, recurse, dryRun bool) bool {
+	p.dryRun = dryRun
+	p.visitFunc = func(path string, dryRun bool) bool {
+		return p.mutator.Relicense(from, path, dryRun)
+	}
+	return p.run(recurse)
+}
+
+// Walk calls visit for every file that isn't ignored. Files are visited concurrently
+func (p *Processor) Walk(recurse bool, visit func(path string) bool) bool {
+	p.visitFunc = func(path string, _ bool) bool { return visit(path) }
+	return p.run(recurse)
+}
+
 func (p *Processor) run(recurse bool) bool {
 	p.success = true
 	if recurse {
@@ -106,10 +150,12 @@ func (p *Processor) visit(path string, f os.FileInfo, err error) error {
 	}
 	if f.Mode().IsRegular() {
 		p.wg.Add(1)
+		p.slots <- struct{}{}
 		go func(path string) {
 			if !p.visitFunc(path, p.dryRun) {
 				p.success = false
 			}
+			<-p.slots
 			p.wg.Done()
 		}(path)
 	}
@@ -134,8 +180,8 @@ func (p *Processor) shouldSkip(path string) bool {
 	if match := p.skipListGitIgnore.Match(path); match != nil && match.Ignore() {
 		return true
 	}
-	// skip .licenserignore
-	if filepath.Base(path) == licenserignoreFile {
+	// skip .licenserignore and the owners file
+	if base := filepath.Base(path); base == licenserignoreFile || base == file.DefaultOwnersFile {
 		return true
 	}
 	// skip according to .licenserignore